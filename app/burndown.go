@@ -0,0 +1,185 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// burndownHeight is the number of rows of the burndown chart.
+	burndownHeight = 8
+	// burndownVelocityDays is the recent period the projection's velocity
+	// is measured over.
+	burndownVelocityDays = 14
+)
+
+// burndownPoint is a task file's scope and completed tasks at the end of a
+// day.
+type burndownPoint struct {
+	Day       time.Time
+	Total     int
+	Completed int
+}
+
+func (bp burndownPoint) Remaining() int {
+	return bp.Total - bp.Completed
+}
+
+// buildBurndown reconstructs a file's progress for each day from its
+// earliest task date to today. A task counts from its earliest date; tasks
+// without dates count from the start.
+func buildBurndown(tasks []Task, today time.Time) []burndownPoint {
+	var start time.Time
+	added := make([]time.Time, len(tasks))
+	completedOn := make([]time.Time, len(tasks))
+
+	for i, task := range tasks {
+		for _, date := range []string{task.StartDate, task.ScheduledDate, task.CompletedDate} {
+			if day, ok := parseDay(date); ok && (added[i].IsZero() || day.Before(added[i])) {
+				added[i] = day
+			}
+		}
+		if day, ok := parseDay(task.CompletedDate); ok {
+			completedOn[i] = day
+		}
+		if !added[i].IsZero() && (start.IsZero() || added[i].Before(start)) {
+			start = added[i]
+		}
+	}
+
+	end := startOfDay(today)
+	if start.IsZero() || start.After(end) {
+		return nil
+	}
+
+	var points []burndownPoint
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		point := burndownPoint{Day: day}
+		for i := range tasks {
+			if !added[i].After(day) {
+				point.Total++
+			}
+			if !completedOn[i].IsZero() && !completedOn[i].After(day) {
+				point.Completed++
+			}
+		}
+		points = append(points, point)
+	}
+
+	return points
+}
+
+// projectCompletion projects when the remaining tasks are done at the
+// velocity, in tasks per day, of the last burndownVelocityDays.
+func projectCompletion(points []burndownPoint) (time.Time, float64, bool) {
+	if len(points) == 0 {
+		return time.Time{}, 0, false
+	}
+
+	last := points[len(points)-1]
+	from := points[max(len(points)-1-burndownVelocityDays, 0)]
+	days := daysBetween(from.Day, last.Day)
+	if days == 0 {
+		days = 1
+	}
+
+	velocity := float64(last.Completed-from.Completed) / float64(days)
+	if last.Remaining() == 0 || velocity <= 0 {
+		return time.Time{}, velocity, false
+	}
+
+	return last.Day.AddDate(0, 0, int(math.Ceil(float64(last.Remaining())/velocity))), velocity, true
+}
+
+// renderBurndown draws the remaining tasks of each day as bars, with the
+// completed ones stacked above up to the file's scope, and the projected
+// completion below.
+func renderBurndown(tasks []Task, today time.Time, width int) string {
+	points := buildBurndown(tasks, today)
+	if len(points) == 0 {
+		return ""
+	}
+
+	columns := min(len(points), max(width-10, 10))
+	highest := 0
+	for _, point := range points {
+		highest = max(highest, point.Total)
+	}
+	if highest == 0 {
+		return ""
+	}
+
+	sample := func(column int) burndownPoint {
+		return points[column*len(points)/columns]
+	}
+	level := func(count int) int {
+		return int(math.Round(float64(count) * burndownHeight / float64(highest)))
+	}
+
+	rows := []string{summaryTitleStyle(width).Render("Burndown")}
+	for row := burndownHeight; row > 0; row-- {
+		label := "    "
+		if row == burndownHeight {
+			label = fmt.Sprintf("%3d ", highest)
+		}
+
+		var line strings.Builder
+		for column := 0; column < columns; column++ {
+			point := sample(column)
+			switch {
+			case level(point.Remaining()) >= row:
+				line.WriteString(scheduledTextStyle.Render("█"))
+			case level(point.Total) >= row:
+				line.WriteString(completedTextStyle.Render("░"))
+			default:
+				line.WriteString(" ")
+			}
+		}
+		rows = append(rows, taskDateStyle().Render(label+"│")+line.String())
+	}
+
+	first, last := points[0], points[len(points)-1]
+	axis := first.Day.Format("Jan 2")
+	if columns > len(axis)+6 {
+		axis += strings.Repeat(" ", columns-len(axis)-6) + last.Day.Format("Jan 2")
+	}
+	rows = append(rows,
+		taskDateStyle().Render("  0 └"+strings.Repeat("─", columns)),
+		taskDateStyle().Render("     "+axis),
+		lipgloss.JoinHorizontal(lipgloss.Top, scheduledTextStyle.Render("█ remaining  "), completedTextStyle.Render("░ completed")),
+		renderProjection(points),
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+func renderProjection(points []burndownPoint) string {
+	last := points[len(points)-1]
+	done := fmt.Sprintf("%d of %d done", last.Completed, last.Total)
+
+	if last.Remaining() == 0 {
+		return completedTextStyle.Render(done)
+	}
+
+	projected, velocity, ok := projectCompletion(points)
+	if !ok {
+		return defaultTextStyle.Render(done + fmt.Sprintf(" · no completions in the last %d days to project from", burndownVelocityDays))
+	}
+
+	return defaultTextStyle.Render(done + fmt.Sprintf(" · %.1f tasks/week · projected %s", velocity*7, projected.Format("Mon Jan 2")))
+}
+
+// burndownView is the burndown of the selected task file in the details
+// view.
+func burndownView(m *Model) string {
+	if m.DirectoryManager.SelectedCategory != "tasks" || m.FileManager.SelectedFile.Name == "" {
+		return ""
+	}
+
+	tasks := m.TaskManager.TaskCollection.TasksByFile[m.FileManager.SelectedFile.Name]
+	return renderBurndown(tasks, time.Now(), m.ViewManager.DetailsViewWidth)
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildBurndown(t *testing.T) {
+	tasks := []Task{
+		{ScheduledDate: "2024-03-01", CompletedDate: "2024-03-02"},
+		{StartDate: "2024-03-03", CompletedDate: "2024-03-04"},
+		{ScheduledDate: "2024-03-04"},
+		{Text: "Undated"},
+	}
+
+	points := buildBurndown(tasks, day(2024, 3, 5))
+
+	want := []struct{ total, completed int }{{2, 0}, {2, 1}, {3, 1}, {4, 2}, {4, 2}}
+	if len(points) != len(want) || !points[0].Day.Equal(day(2024, 3, 1)) {
+		t.Fatalf("expected a point for each day from Mar 1 to Mar 5, got %+v", points)
+	}
+	for i, w := range want {
+		if points[i].Total != w.total || points[i].Completed != w.completed {
+			t.Errorf("day %d: expected %d of %d done, got %+v", i, w.completed, w.total, points[i])
+		}
+	}
+
+	if points := buildBurndown([]Task{{Text: "Undated"}}, day(2024, 3, 5)); points != nil {
+		t.Errorf("expected no burndown without dates, got %+v", points)
+	}
+}
+
+func TestProjectCompletion(t *testing.T) {
+	tests := []struct {
+		name   string
+		tasks  []Task
+		want   string
+		wantOK bool
+	}{
+		{
+			name: "recent velocity",
+			tasks: []Task{
+				{ScheduledDate: "2024-03-01", CompletedDate: "2024-03-03"},
+				{ScheduledDate: "2024-03-01", CompletedDate: "2024-03-05"},
+				{ScheduledDate: "2024-03-01"},
+				{ScheduledDate: "2024-03-01"},
+			},
+			// 2 tasks in 4 days leave 2 remaining for 4 more days
+			want:   "2024-03-09",
+			wantOK: true,
+		},
+		{
+			name:  "no recent completions",
+			tasks: []Task{{ScheduledDate: "2024-03-01"}},
+		},
+		{
+			name:  "all done",
+			tasks: []Task{{ScheduledDate: "2024-03-01", CompletedDate: "2024-03-02"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projected, _, ok := projectCompletion(buildBurndown(tt.tasks, day(2024, 3, 5)))
+			if ok != tt.wantOK || (ok && projected.Format("2006-01-02") != tt.want) {
+				t.Errorf("expected %q (%v), got %s (%v)", tt.want, tt.wantOK, projected, ok)
+			}
+		})
+	}
+}
+
+func TestRenderBurndown(t *testing.T) {
+	tasks := []Task{
+		{ScheduledDate: "2024-03-01", CompletedDate: "2024-03-03"},
+		{ScheduledDate: "2024-03-01"},
+	}
+
+	view := renderBurndown(tasks, day(2024, 3, 5), 80)
+
+	for _, want := range []string{"█", "░", "Mar 1", "1 of 2 done", "projected Sat Mar 9"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected the burndown to contain %q, got\n%s", want, view)
+		}
+	}
+}
@@ -0,0 +1,449 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"vision/caldav"
+	"vision/ical"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+)
+
+const (
+	// syncTimeout bounds a CalDAV sync, so an unreachable server does not
+	// leave the navbar syncing forever.
+	syncTimeout = 30 * time.Second
+	// syncTickInterval is how often the selected company is synced, so
+	// changes made on the phone arrive on their own.
+	syncTickInterval = 5 * time.Minute
+)
+
+type syncStatus int
+
+const (
+	syncIdle syncStatus = iota
+	syncRunning
+	syncDone
+	syncFailed
+)
+
+// SyncState is what the navbar shows of the last CalDAV sync.
+type SyncState struct {
+	Status syncStatus
+	Last   time.Time
+	Result SyncResult
+	Err    error
+}
+
+// SyncResult counts the changes of a sync. Conflicts name the tasks changed
+// on both sides: the latest change wins, or the task is retried on the next
+// sync when the server changed it during the sync. Changes are the task
+// updates pulled from the server, applied to the task files by update.
+type SyncResult struct {
+	Pushed    int
+	Pulled    int
+	Deleted   int
+	Conflicts []string
+	Changes   []syncChange
+}
+
+// syncChange is a task update pulled from the server: a status, or a new
+// scheduled Date when rescheduled. Record is the todo's sync state once the
+// change is applied, nil when the task is no longer synced.
+type syncChange struct {
+	UID    string
+	Task   Task
+	Status string
+	Date   string
+	Record *syncRecord
+}
+
+func (sr *SyncResult) pull(uid string, task Task, status string, date string, record *syncRecord) {
+	sr.Changes = append(sr.Changes, syncChange{UID: uid, Task: task, Status: status, Date: date, Record: record})
+	sr.Pulled++
+}
+
+// applySyncChanges writes the pulled changes to the task files and records
+// the sync state of the ones written, so a change that failed is pulled
+// again on the next sync.
+func applySyncChanges(fm *FileManager, changes []syncChange, records syncRecords) error {
+	var tm TaskManager
+	var errs []error
+
+	for _, change := range changes {
+		var err error
+		if change.Status == "rescheduled" {
+			err = tm.UpdateTaskToRescheduled(fm, change.Task, change.Date)
+		} else {
+			err = fm.UpdateTask(change.Task, change.Status)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if change.Record != nil {
+			records[change.UID] = *change.Record
+		} else {
+			delete(records, change.UID)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// syncRecord is the last agreed state of a synced task.
+type syncRecord struct {
+	Company string `json:"company"`
+	Href    string `json:"href"`
+	ETag    string `json:"etag"`
+	Date    string `json:"date"`
+}
+
+// syncRecords are the synced tasks by UID.
+type syncRecords map[string]syncRecord
+
+func syncStatePath() string {
+	return filepath.Join(notesPath(), ".vision", "caldav.json")
+}
+
+func loadSyncRecords(path string) syncRecords {
+	records := make(syncRecords)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return records
+	}
+
+	if err := json.Unmarshal(data, &records); err != nil {
+		log.Warn("Failed to read the CalDAV sync state", "path", path, "error", err)
+		return make(syncRecords)
+	}
+
+	return records
+}
+
+func (sr syncRecords) save(path string) error {
+	data, err := json.MarshalIndent(sr, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+type localTodo struct {
+	task    Task
+	file    IndexedFile
+	summary string
+}
+
+type remoteTodo struct {
+	object caldav.Object
+	todo   ical.Todo
+}
+
+// todoUID identifies a task's todo the way its exported events are, so the
+// UID survives rescheduling.
+func todoUID(company Company, file IndexedFile, identity string) string {
+	return taskUID(company, file.File.Name, identity, "todo")
+}
+
+// syncTasks syncs the company's scheduled tasks with its CalDAV collection.
+// Dates changed on the server are returned as the result's Changes, whose
+// records are left for applySyncChanges; dates changed locally are pushed.
+func syncTasks(ctx context.Context, client *caldav.Client, company Company, files []IndexedFile, records syncRecords) (SyncResult, error) {
+	var result SyncResult
+
+	local := make(map[string]localTodo)
+	eachOpenTask(company, files, func(file IndexedFile, task Task, identity string) {
+		if task.ScheduledDate != "" {
+			local[todoUID(company, file, identity)] = localTodo{task: task, file: file, summary: taskTitle(task)}
+		}
+	})
+
+	objects, err := client.Todos(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	remote := make(map[string]remoteTodo)
+	for _, object := range objects {
+		calendar, err := ical.Parse(strings.NewReader(object.Data))
+		if err != nil {
+			log.Warn("Skipping unreadable todo", "href", object.Href, "error", err)
+			continue
+		}
+		for _, err := range calendar.Skipped {
+			log.Warn("Skipping unreadable todo", "href", object.Href, "error", err)
+		}
+
+		for _, todo := range calendar.Todos {
+			if strings.HasSuffix(todo.UID, "@vision") {
+				remote[todo.UID] = remoteTodo{object: object, todo: todo}
+			}
+		}
+	}
+
+	var uids []string
+	for uid := range local {
+		uids = append(uids, uid)
+	}
+
+	recorded, found := 0, 0
+	for uid, record := range records {
+		if record.Company != company.FolderPathName {
+			continue
+		}
+		recorded++
+		if _, ok := remote[uid]; ok {
+			found++
+		}
+		if local[uid].summary == "" {
+			uids = append(uids, uid)
+		}
+	}
+	slices.Sort(uids)
+
+	// None of the synced todos being on the server points at a wrong URL or a
+	// re-created collection rather than todos deleted on the phone
+	collectionLost := recorded > 0 && found == 0
+	refused := 0
+
+	for _, uid := range uids {
+		l, isLocal := local[uid]
+		r, isRemote := remote[uid]
+		record, synced := records[uid]
+
+		var err error
+		switch {
+		case isLocal && isRemote:
+			err = syncBoth(ctx, client, company, uid, l, r, record, synced, records, &result)
+
+		case isLocal && synced && collectionLost:
+			refused++
+
+		case isLocal && synced:
+			// Deleted on the server
+			result.pull(uid, l.task, "unscheduled", "", nil)
+
+		case isLocal:
+			err = pushTodo(ctx, client, company, uid, l, nil, records, &result)
+
+		case isRemote:
+			// Completed, unscheduled or removed locally
+			err = client.Delete(ctx, r.object.Href, r.object.ETag)
+			if err == nil {
+				delete(records, uid)
+				result.Deleted++
+			}
+
+		default:
+			delete(records, uid)
+		}
+
+		if errors.Is(err, caldav.ErrConflict) {
+			// Changed on the server during the sync, retried next time
+			name := l.summary
+			if name == "" {
+				name = r.todo.Summary
+			}
+			result.Conflicts = append(result.Conflicts, name)
+		} else if err != nil {
+			return result, err
+		}
+	}
+
+	if refused > 0 {
+		return result, fmt.Errorf("none of the %d synced todos are in the collection, not unscheduling their tasks", refused)
+	}
+
+	return result, nil
+}
+
+// syncBoth reconciles a task with its todo. The side that changed since the
+// last sync wins; when both changed, the latest change does.
+func syncBoth(ctx context.Context, client *caldav.Client, company Company, uid string, l localTodo, r remoteTodo, record syncRecord, synced bool, records syncRecords, result *SyncResult) error {
+	remoteDate := ""
+	if !r.todo.Due.IsZero() {
+		remoteDate = r.todo.Due.Format("2006-01-02")
+	}
+
+	if r.todo.Completed {
+		result.pull(uid, l.task, "completed", "", nil)
+		return client.Delete(ctx, r.object.Href, r.object.ETag)
+	}
+
+	localChanged := !synced || l.task.ScheduledDate != record.Date
+	remoteChanged := synced && remoteDate != record.Date
+
+	if localChanged && remoteChanged && remoteDate != l.task.ScheduledDate {
+		result.Conflicts = append(result.Conflicts, l.summary)
+		localChanged = !r.todo.LastModified.After(l.file.File.UpdatedAt)
+		remoteChanged = !localChanged
+	}
+
+	switch {
+	case remoteChanged && remoteDate == "":
+		result.pull(uid, l.task, "unscheduled", "", nil)
+		return client.Delete(ctx, r.object.Href, r.object.ETag)
+
+	case remoteChanged:
+		result.pull(uid, l.task, "rescheduled", remoteDate, &syncRecord{Company: company.FolderPathName, Href: r.object.Href, ETag: r.object.ETag, Date: remoteDate})
+		return nil
+
+	case localChanged && remoteDate != l.task.ScheduledDate:
+		return pushTodo(ctx, client, company, uid, l, &r, records, result)
+	}
+
+	records[uid] = syncRecord{Company: company.FolderPathName, Href: r.object.Href, ETag: r.object.ETag, Date: remoteDate}
+	return nil
+}
+
+// pushTodo writes the task's todo, creating it when there is no remote one.
+// An existing todo only has its due date and modification time patched, so
+// alarms, notes or categories added on the phone are kept.
+func pushTodo(ctx context.Context, client *caldav.Client, company Company, uid string, l localTodo, remote *remoteTodo, records syncRecords, result *SyncResult) error {
+	due, err := time.ParseInLocation("2006-01-02", l.task.ScheduledDate, time.Local)
+	if err != nil {
+		return nil // Not a date, nothing to sync
+	}
+
+	href := client.Href(strings.TrimSuffix(uid, "@vision") + ".ics")
+	etag := ""
+	var data []byte
+
+	if remote != nil {
+		patched, err := ical.PatchTodo(remote.object.Data, uid, due, true, l.file.File.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		href, etag, data = remote.object.Href, remote.object.ETag, []byte(patched)
+	} else {
+		var buffer bytes.Buffer
+		todo := ical.Todo{
+			UID:          uid,
+			Summary:      l.summary,
+			Description:  company.DisplayName + " / " + l.file.File.Name,
+			Due:          due,
+			AllDay:       true,
+			LastModified: l.file.File.UpdatedAt,
+		}
+		if err := ical.EncodeTodo(&buffer, icsProdID, todo, time.Now()); err != nil {
+			return err
+		}
+		data = buffer.Bytes()
+	}
+
+	newETag, err := client.Put(ctx, href, data, etag)
+	if err != nil {
+		return err
+	}
+
+	records[uid] = syncRecord{Company: company.FolderPathName, Href: href, ETag: newETag, Date: l.task.ScheduledDate}
+	result.Pushed++
+	return nil
+}
+
+// syncCmd syncs the selected company's scheduled tasks in the background.
+func (m *Model) syncCmd() tea.Cmd {
+	company := m.DirectoryManager.SelectedCompany
+	if company.CalDAV.URL == "" {
+		return nil
+	}
+
+	m.Sync.Status = syncRunning
+	index := m.FileManager.Index
+	extension := m.FileManager.FileExtension
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+		defer cancel()
+
+		msg := CalDAVSyncedMsg{Company: company.DisplayName, Time: time.Now()}
+
+		taskPath := filepath.Join(notesPath(), company.FolderPathName, "tasks")
+		files, err := readNotes(ctx, taskPath, index, extension, false, nil)
+		saveIndex(index)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+
+		client := caldav.NewClient(company.CalDAV.URL, company.CalDAV.Username, company.CalDAV.Password)
+		msg.Records = loadSyncRecords(syncStatePath())
+		msg.Result, msg.Err = syncTasks(ctx, client, company, files, msg.Records)
+
+		return msg
+	}
+}
+
+// syncTickCmd schedules the next periodic sync
+func syncTickCmd() tea.Cmd {
+	return tea.Tick(syncTickInterval, func(t time.Time) tea.Msg {
+		return SyncTickMsg{Time: t}
+	})
+}
+
+// hasCalDAV reports whether any company syncs with a CalDAV collection.
+func hasCalDAV(companies []Company) bool {
+	return slices.ContainsFunc(companies, func(company Company) bool { return company.CalDAV.URL != "" })
+}
+
+// saveSyncRecords writes the sync state once the pulled changes are applied.
+func saveSyncRecords(records syncRecords) {
+	if records == nil {
+		return
+	}
+
+	if err := records.save(syncStatePath()); err != nil {
+		log.Warn("Failed to save the CalDAV sync state", "error", err)
+	}
+}
+
+// ApplySync stores the result of a sync.
+func (ss *SyncState) ApplySync(msg CalDAVSyncedMsg) {
+	ss.Last = msg.Time
+	ss.Result = msg.Result
+	ss.Err = msg.Err
+	ss.Status = syncDone
+
+	if msg.Err != nil {
+		log.Warn("CalDAV sync failed", "company", msg.Company, "error", msg.Err)
+		ss.Status = syncFailed
+	}
+}
+
+// renderSyncStatus shows the state of the last sync in the navbar.
+func renderSyncStatus(m *Model) string {
+	if m.DirectoryManager.SelectedCompany.CalDAV.URL == "" {
+		return ""
+	}
+
+	switch m.Sync.Status {
+	case syncRunning:
+		return loadingTextStyle.Render("⟳ Syncing")
+	case syncFailed:
+		return overdueTextStyle.Render("✗ Sync failed")
+	case syncDone:
+		if conflicts := len(m.Sync.Result.Conflicts); conflicts > 0 {
+			return scheduledTextStyle.Render(fmt.Sprintf("⚠ Synced %s, %d conflicts", m.Sync.Last.Format("15:04"), conflicts))
+		}
+		return completedTextStyle.Render("✓ Synced " + m.Sync.Last.Format("15:04"))
+	}
+
+	return loadingTextStyle.Render("Not synced")
+}
@@ -0,0 +1,223 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"vision/caldav"
+	"vision/caldav/caldavtest"
+	"vision/config"
+	"vision/ical"
+	"vision/mindmap"
+)
+
+func TestSyncTasks(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	server := caldavtest.NewServer()
+	defer server.Close()
+
+	company := Company{DisplayName: "Clerky", FolderPathName: "clerky", CalDAV: config.CalDAV{URL: server.CollectionURL()}}
+	client := caldav.NewClient(company.CalDAV.URL, "", "")
+	fm := &FileManager{FileExtension: ".md", Updater: mindmap.NewNullUpdater()}
+	records := make(syncRecords)
+
+	taskDir := filepath.Join(home, "Notes", "clerky", "tasks")
+	taskFile := filepath.Join(taskDir, "Release.md")
+	writeTestNotes(t, taskDir, map[string]string{
+		"Release.md": "# Release\n- [ ] Ship it ⏳ 2024-03-05\n- [ ] Write docs ⏳ 2024-03-06\n- [ ] Someday\n",
+	})
+
+	sync := func() SyncResult {
+		t.Helper()
+		index := OpenFileIndex(filepath.Join(t.TempDir(), "index.json"))
+		files, err := readNotes(context.Background(), taskDir, index, ".md", false, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := syncTasks(context.Background(), client, company, files, records)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := applySyncChanges(fm, result.Changes, records); err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	// The phone edits a todo the way a reminders app does
+	phone := func(summary string, edit func(*ical.Todo)) {
+		t.Helper()
+		for _, href := range server.Hrefs() {
+			data, _ := server.Get(href)
+			calendar, err := ical.Parse(strings.NewReader(data))
+			if err != nil || calendar.Todos[0].Summary != summary {
+				continue
+			}
+
+			todo := calendar.Todos[0]
+			edit(&todo)
+
+			var buffer bytes.Buffer
+			if err := ical.EncodeTodo(&buffer, "-//phone//EN", todo, time.Now()); err != nil {
+				t.Fatal(err)
+			}
+			server.Set(href, buffer.String())
+			return
+		}
+		t.Fatalf("no todo %q on the server", summary)
+	}
+
+	editFile := func(old, new string) {
+		t.Helper()
+		content, _ := os.ReadFile(taskFile)
+		if err := os.WriteFile(taskFile, []byte(strings.Replace(string(content), old, new, 1)), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assertFile := func(want string) {
+		t.Helper()
+		if content, _ := os.ReadFile(taskFile); !strings.Contains(string(content), want) {
+			t.Errorf("expected the task file to contain %q, got %q", want, content)
+		}
+	}
+
+	if result := sync(); result.Pushed != 2 || len(server.Hrefs()) != 2 {
+		t.Fatalf("expected the 2 scheduled tasks to be pushed, got %+v", result)
+	}
+
+	if result := sync(); result.Pushed+result.Pulled+result.Deleted != 0 {
+		t.Errorf("expected an unchanged sync to do nothing, got %+v", result)
+	}
+
+	t.Run("rescheduled on the phone", func(t *testing.T) {
+		phone("Ship it", func(todo *ical.Todo) { todo.Due = time.Date(2024, 3, 10, 0, 0, 0, 0, time.Local) })
+
+		if result := sync(); result.Pulled != 1 {
+			t.Errorf("expected the new date to be pulled, got %+v", result)
+		}
+		assertFile("- [ ] Ship it ⏳ 2024-03-10\n")
+	})
+
+	t.Run("rescheduled locally", func(t *testing.T) {
+		editFile("Write docs ⏳ 2024-03-06", "Write docs ⏳ 2024-03-08")
+
+		if result := sync(); result.Pushed != 1 {
+			t.Errorf("expected the new date to be pushed, got %+v", result)
+		}
+		phone("Write docs", func(todo *ical.Todo) {
+			if todo.Due.Format("2006-01-02") != "2024-03-08" {
+				t.Errorf("expected the todo to be due 2024-03-08, got %v", todo.Due)
+			}
+		})
+	})
+
+	t.Run("rescheduled locally keeps the phone's additions", func(t *testing.T) {
+		for _, href := range server.Hrefs() {
+			data, _ := server.Get(href)
+			if strings.Contains(data, "SUMMARY:Write docs") {
+				server.Set(href, strings.Replace(data, "END:VTODO", "CATEGORIES:work\r\nBEGIN:VALARM\r\nACTION:DISPLAY\r\nTRIGGER:-PT15M\r\nEND:VALARM\r\nEND:VTODO", 1))
+			}
+		}
+		sync()
+		editFile("Write docs ⏳ 2024-03-08", "Write docs ⏳ 2024-03-09")
+
+		if result := sync(); result.Pushed != 1 {
+			t.Errorf("expected the new date to be pushed, got %+v", result)
+		}
+		for _, href := range server.Hrefs() {
+			data, _ := server.Get(href)
+			if strings.Contains(data, "SUMMARY:Write docs") && (!strings.Contains(data, "CATEGORIES:work") || !strings.Contains(data, "TRIGGER:-PT15M") || !strings.Contains(data, "DUE;VALUE=DATE:20240309")) {
+				t.Errorf("expected the todo to keep its category and alarm, got %q", data)
+			}
+		}
+	})
+
+	t.Run("rescheduled on both sides", func(t *testing.T) {
+		phone("Ship it", func(todo *ical.Todo) {
+			todo.Due = time.Date(2024, 3, 11, 0, 0, 0, 0, time.Local)
+			todo.LastModified = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		})
+		editFile("Ship it ⏳ 2024-03-10", "Ship it ⏳ 2024-03-12")
+
+		result := sync()
+		if len(result.Conflicts) != 1 || result.Conflicts[0] != "Ship it" || result.Pushed != 1 {
+			t.Errorf("expected the latest, local change to win the conflict, got %+v", result)
+		}
+		assertFile("Ship it ⏳ 2024-03-12")
+	})
+
+	t.Run("completed on the phone", func(t *testing.T) {
+		phone("Write docs", func(todo *ical.Todo) { todo.Completed = true })
+
+		if result := sync(); result.Pulled != 1 || len(server.Hrefs()) != 1 {
+			t.Errorf("expected the task to be completed and its todo removed, got %+v with %v", result, server.Hrefs())
+		}
+		assertFile("- [x] Write docs ⏳ 2024-03-09 " + CompletedIcon)
+	})
+
+	t.Run("empty collection", func(t *testing.T) {
+		hrefs := server.Hrefs()
+		saved := make(map[string]string)
+		for _, href := range hrefs {
+			saved[href], _ = server.Get(href)
+			server.Delete(href)
+		}
+
+		index := OpenFileIndex(filepath.Join(t.TempDir(), "index.json"))
+		files, _ := readNotes(context.Background(), taskDir, index, ".md", false, nil)
+		result, err := syncTasks(context.Background(), client, company, files, records)
+		if err == nil || len(result.Changes) != 0 {
+			t.Errorf("expected the sync to refuse unscheduling every task, got %+v (%v)", result, err)
+		}
+		assertFile("Ship it ⏳ 2024-03-12")
+
+		for href, data := range saved {
+			server.Set(href, data)
+		}
+	})
+
+	t.Run("completed locally", func(t *testing.T) {
+		editFile("- [ ] Ship it", "- [x] Ship it")
+
+		if result := sync(); result.Deleted != 1 || len(server.Hrefs()) != 0 || len(records) != 0 {
+			t.Errorf("expected the todo to be deleted, got %+v with %v", result, server.Hrefs())
+		}
+	})
+}
+
+func TestApplySyncChangesRecordsOnlyWrittenChanges(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	taskDir := filepath.Join(home, "Notes", "clerky", "tasks")
+	writeTestNotes(t, taskDir, map[string]string{"Release.md": "# Release\n- [ ] Ship it ⏳ 2024-03-05\n"})
+
+	fm := &FileManager{FileExtension: ".md", Updater: mindmap.NewNullUpdater()}
+	records := syncRecords{
+		"ship@vision":    {Company: "clerky", Date: "2024-03-05"},
+		"renamed@vision": {Company: "clerky", Date: "2024-03-05"},
+	}
+	changes := []syncChange{
+		{UID: "ship@vision", Task: Task{Company: "clerky", FileName: "Release.md", Text: " Ship it ⏳ 2024-03-05"}, Status: "rescheduled", Date: "2024-03-10", Record: &syncRecord{Company: "clerky", Date: "2024-03-10"}},
+		{UID: "renamed@vision", Task: Task{Company: "clerky", FileName: "Release.md", Text: " Write docs ⏳ 2024-03-05"}, Status: "rescheduled", Date: "2024-03-10", Record: &syncRecord{Company: "clerky", Date: "2024-03-10"}},
+	}
+
+	if err := applySyncChanges(fm, changes, records); err == nil {
+		t.Error("expected the change of a task that is no longer in the file to fail")
+	}
+
+	if records["ship@vision"].Date != "2024-03-10" {
+		t.Errorf("expected the applied change to be recorded, got %+v", records["ship@vision"])
+	}
+	if records["renamed@vision"].Date != "2024-03-05" {
+		t.Errorf("expected the failed change to be pulled again, got %+v", records["renamed@vision"])
+	}
+}
@@ -0,0 +1,211 @@
+package app
+
+import (
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// calendarWeeks is the number of weeks the week layout shows.
+const calendarWeeks = 4
+
+// CalendarControl handles the keys of the calendar view: moving the day
+// cursor, paging, layouts and moving tasks between days
+type CalendarControl struct{}
+
+var calendarKeys = []string{"h", "j", "k", "l", "left", "down", "up", "right", "[", "]", "E", "v", "enter"}
+
+// Handles reports whether the calendar takes the key. Esc is only taken to
+// close a day or cancel a move.
+func (cc CalendarControl) Handles(key string, m *Model) bool {
+	if !m.IsCalendarNavigation() {
+		return false
+	}
+
+	if key == "esc" {
+		return m.Calendar.DayOpen || m.Calendar.Moving != nil
+	}
+
+	return slices.Contains(calendarKeys, key)
+}
+
+// HandleKey routes calendar keys to their handlers
+func (cc CalendarControl) HandleKey(key string, m *Model) tea.Cmd {
+	cs := &m.Calendar
+
+	switch key {
+	case "h", "left":
+		cs.MoveCursor(-1)
+	case "l", "right":
+		cs.MoveCursor(1)
+	case "j", "down":
+		if cs.DayOpen {
+			goToNext(&cs.TaskCursor, len(m.calendarDayTasks()))
+		} else {
+			cs.MoveCursor(7)
+		}
+	case "k", "up":
+		if cs.DayOpen {
+			goToPrevious(&cs.TaskCursor)
+		} else {
+			cs.MoveCursor(-7)
+		}
+	case "]":
+		cs.Page(1)
+	case "[":
+		cs.Page(-1)
+	case "E":
+		cs.ShowWeekends = !cs.ShowWeekends
+		cs.MoveCursor(0)
+	case "v":
+		cs.MonthLayout = !cs.MonthLayout
+		cs.MoveCursor(0)
+	case "enter":
+		return cc.Select(m)
+	case "esc":
+		cs.Moving = nil
+		cs.DayOpen = false
+	}
+
+	return nil
+}
+
+// Select drops the task being moved on the cursor's day, picks the task
+// under the cursor of an open day, or opens the cursor's day
+func (cc CalendarControl) Select(m *Model) tea.Cmd {
+	cs := &m.Calendar
+
+	switch {
+	case cs.Moving != nil:
+		task := *cs.Moving
+		cs.Moving = nil
+
+		if err := m.TaskManager.UpdateTaskToRescheduled(&m.FileManager, task, cs.CursorDay().Format("2006-01-02")); err != nil {
+			m.Errors = append(m.Errors, err.Error())
+			return nil
+		}
+		m.FileManager.RequestRefresh()
+
+	case cs.DayOpen:
+		tasks := m.calendarDayTasks()
+		if cs.TaskCursor >= len(tasks) {
+			return nil
+		}
+
+		task := tasks[cs.TaskCursor]
+		if task.Completed {
+			m.Errors = append(m.Errors, "Completed tasks cannot be moved")
+			return nil
+		}
+
+		cs.Moving = &task
+		cs.DayOpen = false
+
+	default:
+		cs.DayOpen = true
+		cs.TaskCursor = 0
+	}
+
+	return nil
+}
+
+// IsCalendarNavigation reports whether the calendar view is shown and takes
+// the navigation keys
+func (m *Model) IsCalendarNavigation() bool {
+	return m.ViewManager.IsCalendarView && m.IsKanbanView() &&
+		!m.IsNewTaskInputView() && !m.IsFilterView() && !m.IsSearchView()
+}
+
+func (m *Model) calendarDayTasks() []Task {
+	return tasksOnDate(m.TaskManager.TaskCollection.allTasks(), m.Calendar.CursorDay())
+}
+
+// CursorDay is the selected day, today until the cursor moves.
+func (cs CalendarState) CursorDay() time.Time {
+	if cs.Cursor.IsZero() {
+		return startOfDay(time.Now())
+	}
+	return cs.Cursor
+}
+
+// MoveCursor moves the cursor by days, stepping over weekends when they are
+// hidden, and keeps it on the shown weeks.
+func (cs *CalendarState) MoveCursor(days int) {
+	cursor := cs.CursorDay().AddDate(0, 0, days)
+
+	step := 1
+	if days < 0 {
+		step = -1
+	}
+	for !cs.ShowWeekends && isWeekend(cursor) {
+		cursor = cursor.AddDate(0, 0, step)
+	}
+
+	cs.Cursor = cursor
+	cs.TaskCursor = 0
+
+	start, weeks := cs.Grid()
+	if cursor.Before(start) {
+		cs.Start = mondayOf(cursor)
+	} else if end := start.AddDate(0, 0, 7*weeks); !cursor.Before(end) {
+		cs.Start = mondayOf(cursor).AddDate(0, 0, -7*(weeks-1))
+	}
+}
+
+// Page moves the cursor and the shown weeks a month, or a page of weeks,
+// forward or back.
+func (cs *CalendarState) Page(direction int) {
+	if cs.MonthLayout {
+		cursor := cs.CursorDay()
+		cs.Cursor = time.Date(cursor.Year(), cursor.Month()+time.Month(direction), 1, 0, 0, 0, 0, time.Local)
+		cs.MoveCursor(0)
+		return
+	}
+
+	start, weeks := cs.Grid()
+	cs.Start = start.AddDate(0, 0, 7*weeks*direction)
+	cs.Cursor = cs.CursorDay().AddDate(0, 0, 7*weeks*direction)
+	cs.MoveCursor(0)
+}
+
+// Grid returns the first Monday shown and the number of weeks: the weeks of
+// the cursor's month, or four weeks that end with today's until the cursor
+// leaves them.
+func (cs CalendarState) Grid() (time.Time, int) {
+	if cs.MonthLayout {
+		cursor := cs.CursorDay()
+		first := time.Date(cursor.Year(), cursor.Month(), 1, 0, 0, 0, 0, time.Local)
+		last := first.AddDate(0, 1, -1)
+		start := mondayOf(first)
+
+		weeks := 0
+		for monday := start; !monday.After(last); monday = monday.AddDate(0, 0, 7) {
+			weeks++
+		}
+		return start, weeks
+	}
+
+	if cs.Start.IsZero() {
+		return mondayOf(time.Now()).AddDate(0, 0, -7*(calendarWeeks-1)), calendarWeeks
+	}
+	return cs.Start, calendarWeeks
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// mondayOf returns the start of the Monday of t's week.
+func mondayOf(t time.Time) time.Time {
+	day := startOfDay(t)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
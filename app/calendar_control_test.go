@@ -0,0 +1,144 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"vision/mindmap"
+	"vision/utils"
+)
+
+func day(year int, month time.Month, date int) time.Time {
+	return time.Date(year, month, date, 0, 0, 0, 0, time.Local)
+}
+
+func TestCalendarStateMoveCursor(t *testing.T) {
+	friday := day(2024, 3, 8)
+
+	tests := []struct {
+		name     string
+		state    CalendarState
+		days     int
+		want     time.Time
+		wantFrom time.Time
+	}{
+		{"next day skips the weekend", CalendarState{Cursor: friday, Start: day(2024, 2, 12)}, 1, day(2024, 3, 11), day(2024, 2, 19)},
+		{"previous day skips the weekend", CalendarState{Cursor: day(2024, 3, 11), Start: day(2024, 2, 26)}, -1, friday, day(2024, 2, 26)},
+		{"weekends shown", CalendarState{Cursor: friday, Start: day(2024, 2, 19), ShowWeekends: true}, 1, day(2024, 3, 9), day(2024, 2, 19)},
+		{"week up before the grid", CalendarState{Cursor: day(2024, 2, 20), Start: day(2024, 2, 19)}, -7, day(2024, 2, 13), day(2024, 2, 12)},
+		{"week down after the grid", CalendarState{Cursor: friday, Start: day(2024, 2, 12)}, 7, day(2024, 3, 15), day(2024, 2, 19)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.state.MoveCursor(tt.days)
+			start, _ := tt.state.Grid()
+
+			if !tt.state.Cursor.Equal(tt.want) || !start.Equal(tt.wantFrom) {
+				t.Errorf("expected cursor %s from %s, got %s from %s", tt.want.Format("Jan 2"), tt.wantFrom.Format("Jan 2"), tt.state.Cursor.Format("Jan 2"), start.Format("Jan 2"))
+			}
+		})
+	}
+}
+
+func TestCalendarStateGridAndPaging(t *testing.T) {
+	state := CalendarState{Cursor: day(2024, 3, 13), MonthLayout: true}
+
+	if start, weeks := state.Grid(); !start.Equal(day(2024, 2, 26)) || weeks != 5 {
+		t.Errorf("expected March 2024 to span 5 weeks from Feb 26, got %d from %s", weeks, start)
+	}
+
+	state.Page(-1)
+	if start, weeks := state.Grid(); !state.Cursor.Equal(day(2024, 2, 1)) || !start.Equal(day(2024, 1, 29)) || weeks != 5 {
+		t.Errorf("expected February 2024, got cursor %s and %d weeks from %s", state.Cursor, weeks, start)
+	}
+
+	state = CalendarState{Cursor: day(2021, 2, 10), MonthLayout: true}
+	if _, weeks := state.Grid(); weeks != 4 {
+		t.Errorf("expected February 2021 to span 4 weeks, got %d", weeks)
+	}
+
+	state = CalendarState{Cursor: day(2024, 3, 13), Start: day(2024, 2, 19)}
+	state.Page(1)
+	if start, _ := state.Grid(); !state.Cursor.Equal(day(2024, 4, 10)) || !start.Equal(day(2024, 3, 18)) {
+		t.Errorf("expected the next 4 weeks, got cursor %s from %s", state.Cursor, start)
+	}
+}
+
+func TestCalendarMovesTaskToAnotherDay(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	taskDir := filepath.Join(home, "Notes", "clerky", "tasks")
+	writeTestNotes(t, taskDir, map[string]string{
+		"Release.md": "# Release\n- [ ] Ship it ⏳ 2024-03-05\n- [x] Plan ⏳ 2024-03-01 ✅ 2024-03-05\n",
+	})
+
+	m := &Model{
+		ViewManager: ViewManager{CurrentView: CategoriesView, IsCalendarView: true, HideSidebar: true},
+		FileManager: FileManager{FileExtension: ".md", Updater: mindmap.NewNullUpdater()},
+		Calendar:    CalendarState{Cursor: day(2024, 3, 5), Start: day(2024, 2, 19)},
+	}
+
+	var tm TaskManager
+	content, _ := os.ReadFile(filepath.Join(taskDir, "Release.md"))
+	m.TaskManager.TaskCollection = TaskCollection{TasksByFile: map[string][]Task{
+		"Release.md": tm.CreateTasks(Company{DisplayName: "Clerky", FolderPathName: "clerky"}, "Release.md", utils.ExtractTasksFromText(string(content))),
+	}}
+
+	press := func(keys ...string) {
+		for _, key := range keys {
+			if !(CalendarControl{}).Handles(key, m) {
+				t.Fatalf("expected the calendar to handle %q", key)
+			}
+			CalendarControl{}.HandleKey(key, m)
+		}
+	}
+
+	press("enter")
+	if !m.Calendar.DayOpen || len(m.calendarDayTasks()) != 2 {
+		t.Fatalf("expected the day to list its 2 tasks, got %+v", m.calendarDayTasks())
+	}
+
+	// The completed task cannot be moved
+	press("j", "enter")
+	if m.Calendar.Moving != nil || len(m.Errors) != 1 {
+		t.Errorf("expected completed tasks to stay, got %+v", m.Calendar.Moving)
+	}
+
+	press("k", "enter")
+	if m.Calendar.Moving == nil || taskTitle(*m.Calendar.Moving) != "Ship it" {
+		t.Fatalf("expected Ship it to be picked, got %+v", m.Calendar.Moving)
+	}
+
+	press("l", "j", "enter")
+	if m.Calendar.Moving != nil || !m.FileManager.refreshRequested {
+		t.Errorf("expected the task to be dropped and the files refreshed")
+	}
+
+	content, _ = os.ReadFile(filepath.Join(taskDir, "Release.md"))
+	if !strings.Contains(string(content), "- [ ] Ship it ⏳ 2024-03-13\n") {
+		t.Errorf("expected Ship it to be rescheduled to Mar 13, got %q", content)
+	}
+
+	if (CalendarControl{}).Handles("esc", m) {
+		t.Errorf("expected esc to go back to the view once nothing is open")
+	}
+}
+
+func TestCalendarTakesKeysOnceFocused(t *testing.T) {
+	m := &Model{ViewManager: ViewManager{CurrentView: CategoriesView, IsCalendarView: true}}
+
+	for _, key := range []string{"j", "enter", "right"} {
+		if (CalendarControl{}).Handles(key, m) {
+			t.Errorf("expected the sidebar to keep %q while the calendar is not focused", key)
+		}
+	}
+
+	FileOperations{}.ToggleSidebar(m)
+	if !(CalendarControl{}).Handles("j", m) {
+		t.Errorf("expected the calendar to take j once the sidebar is hidden")
+	}
+}
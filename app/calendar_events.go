@@ -0,0 +1,198 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"vision/ical"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+)
+
+// CalendarState holds the events of each company's ICS calendars, by
+// company display name, the event the new meeting input was filled from and
+// the calendar view's cursor and layout.
+type CalendarState struct {
+	Events      map[string][]ical.Event
+	EventCursor int
+	Prefilled   *ical.Occurrence
+
+	Cursor       time.Time
+	Start        time.Time
+	ShowWeekends bool
+	MonthLayout  bool
+	DayOpen      bool
+	TaskCursor   int
+	Moving       *Task
+}
+
+// SetEvents replaces the events of a company.
+func (cs *CalendarState) SetEvents(company string, events []ical.Event) {
+	if cs.Events == nil {
+		cs.Events = make(map[string][]ical.Event)
+	}
+	cs.Events[company] = events
+}
+
+// EventsOn returns the company's event occurrences on day.
+func (cs CalendarState) EventsOn(company string, day time.Time) []ical.Occurrence {
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local)
+	return ical.Expand(cs.Events[company], start, start.AddDate(0, 0, 1))
+}
+
+// parsedCalendar is the events of an ICS file as of its modification time.
+type parsedCalendar struct {
+	modTime time.Time
+	size    int64
+	events  []ical.Event
+}
+
+// calendarCache keeps the parsed ICS files by path, so a refresh only parses
+// the calendars changed since the previous one. Loads run in the background,
+// hence the lock.
+var calendarCache = struct {
+	sync.Mutex
+	calendars map[string]parsedCalendar
+}{calendars: make(map[string]parsedCalendar)}
+
+// loadCalendarEvents reads the events of the given ICS files and folders of
+// ICS files. Unreadable calendars are logged and skipped.
+func loadCalendarEvents(paths []string) []ical.Event {
+	var events []ical.Event
+
+	for _, path := range calendarFiles(paths) {
+		calendarEvents, err := readCalendarEvents(path)
+		if err != nil {
+			log.Warn("Failed to read calendar", "path", path, "error", err)
+			continue
+		}
+
+		events = append(events, calendarEvents...)
+	}
+
+	return events
+}
+
+// readCalendarEvents parses the ICS file at path, unless it is unchanged
+// since it was last parsed.
+func readCalendarEvents(path string) ([]ical.Event, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	calendarCache.Lock()
+	cached, ok := calendarCache.calendars[path]
+	calendarCache.Unlock()
+	if ok && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.events, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	calendar, err := ical.Parse(file)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, err := range calendar.Skipped {
+		log.Warn("Skipping calendar component", "path", path, "error", err)
+	}
+
+	calendarCache.Lock()
+	calendarCache.calendars[path] = parsedCalendar{modTime: info.ModTime(), size: info.Size(), events: calendar.Events}
+	calendarCache.Unlock()
+
+	return calendar.Events, nil
+}
+
+// calendarFiles expands ~ in the configured paths and lists the .ics files
+// of folders.
+func calendarFiles(paths []string) []string {
+	var files []string
+
+	for _, path := range paths {
+		path = expandHome(path)
+
+		info, err := os.Stat(path)
+		if err != nil {
+			log.Warn("Calendar not found", "path", path, "error", err)
+			continue
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		matches, _ := filepath.Glob(filepath.Join(path, "*.ics"))
+		files = append(files, matches...)
+	}
+
+	return files
+}
+
+// formatOccurrence shows an event as "09:30 Standup", or "All day Offsite".
+func formatOccurrence(occurrence ical.Occurrence) string {
+	if occurrence.AllDay {
+		return "All day " + occurrence.Summary
+	}
+	return occurrence.Start.Local().Format("15:04") + " " + occurrence.Summary
+}
+
+// renderEvents lists the day's events above the daily summary.
+func renderEvents(occurrences []ical.Occurrence, width int) string {
+	if len(occurrences) == 0 {
+		return ""
+	}
+
+	lines := []string{taskTitleContainer(width).Render(summaryTitleStyle(width).Render("Calendar"))}
+	for _, occurrence := range occurrences {
+		lines = append(lines, eventTextStyle.Render(formatOccurrence(occurrence)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// eventMeetingInput describes an event as new meeting input, keeping the
+// attendees that have a note in the people folder.
+func eventMeetingInput(occurrence ical.Occurrence, people []string) string {
+	input := occurrence.Start.Local().Format("2006-01-02") + " " + strings.TrimSpace(occurrence.Summary)
+
+	for _, attendee := range occurrence.Attendees {
+		if containsFold(people, attendee) {
+			input += " [[" + attendee + "]]"
+		}
+	}
+
+	return input
+}
+
+// eventDetails lists the time, location and description of an event for
+// the meeting note created from it.
+func eventDetails(occurrence ical.Occurrence) string {
+	var details []string
+
+	if occurrence.AllDay {
+		details = append(details, "Time: all day")
+	} else {
+		details = append(details, "Time: "+occurrence.Start.Local().Format("15:04")+"–"+occurrence.End.Local().Format("15:04"))
+	}
+
+	if occurrence.Location != "" {
+		details = append(details, "Location: "+occurrence.Location)
+	}
+
+	if description := strings.TrimSpace(occurrence.Description); description != "" {
+		details = append(details, "", description)
+	}
+
+	return strings.Join(details, "\n")
+}
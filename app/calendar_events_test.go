@@ -0,0 +1,148 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"vision/ical"
+)
+
+const testCalendar = `BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VEVENT
+UID:standup@clerky
+SUMMARY:Standup
+DTSTART:20240304T093000
+DTEND:20240304T094500
+RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR
+ATTENDEE;CN=Jane Doe:mailto:jane@example.com
+ATTENDEE;CN=Unknown Guest:mailto:guest@example.com
+END:VEVENT
+BEGIN:VEVENT
+UID:offsite@clerky
+SUMMARY:Offsite
+LOCATION:Office
+DESCRIPTION:Bring laptops
+DTSTART;VALUE=DATE:20240305
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestLoadCalendarEvents(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	folder := filepath.Join(home, "Calendars", "clerky")
+	writeTestNotes(t, folder, map[string]string{
+		"work.ics":   testCalendar,
+		"broken.ics": "BEGIN:VCALENDAR\n",
+		"notes.txt":  "not a calendar",
+	})
+
+	events := loadCalendarEvents([]string{"~/Calendars/clerky", filepath.Join(home, "missing.ics")})
+	if len(events) != 2 {
+		t.Fatalf("expected the 2 events of the readable calendar, got %d", len(events))
+	}
+
+	var state CalendarState
+	state.SetEvents("Clerky", events)
+
+	tests := []struct {
+		day  time.Time
+		want []string
+	}{
+		{time.Date(2024, 3, 4, 0, 0, 0, 0, time.Local), []string{"09:30 Standup"}},
+		{time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local), []string{"All day Offsite", "09:30 Standup"}},
+		{time.Date(2024, 3, 9, 0, 0, 0, 0, time.Local), nil},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, occurrence := range state.EventsOn("Clerky", tt.day) {
+			got = append(got, formatOccurrence(occurrence))
+		}
+
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("%s: expected %v, got %v", tt.day.Format("Jan 2"), tt.want, got)
+		}
+	}
+
+	if got := state.EventsOn("Qvest.US", tests[0].day); len(got) != 0 {
+		t.Errorf("expected no events for another company, got %v", got)
+	}
+}
+
+func TestMeetingFromEvent(t *testing.T) {
+	calendar, err := ical.Parse(strings.NewReader(testCalendar))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	day := time.Date(2024, 3, 6, 0, 0, 0, 0, time.Local)
+	standup := ical.Expand(calendar.Events, day, day.AddDate(0, 0, 1))[0]
+
+	input := eventMeetingInput(standup, []string{"jane doe", "John Roe"})
+	if input != "2024-03-06 Standup [[Jane Doe]]" {
+		t.Errorf("unexpected input %q", input)
+	}
+
+	meeting := parseMeetingInput(input, day)
+	meeting.Details = eventDetails(standup)
+
+	want := "# Standup\n\nDate: 2024-03-06\nTime: 09:30–09:45\n\n## Attendees\n- [[Jane Doe]]\n"
+	if got := meeting.render(defaultMeetingTemplate); !strings.HasPrefix(got, want) {
+		t.Errorf("expected note to start with %q, got %q", want, got)
+	}
+
+	offsite := ical.Expand(calendar.Events, day.AddDate(0, 0, -1), day)[0]
+	if got := eventDetails(offsite); got != "Time: all day\nLocation: Office\n\nBring laptops" {
+		t.Errorf("unexpected details %q", got)
+	}
+}
+
+func TestCalendarFilesListsFolders(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.ics"), []byte(testCalendar), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	single := filepath.Join(dir, "a.ics")
+	if got := calendarFiles([]string{dir, single}); len(got) != 2 || got[0] != single || got[1] != single {
+		t.Errorf("expected the folder's file and the file itself, got %v", got)
+	}
+}
+
+func TestLoadCalendarEventsReusesUnchangedCalendars(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	path := filepath.Join(home, "work.ics")
+	writeTestNotes(t, home, map[string]string{"work.ics": testCalendar})
+	modified := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+
+	if events := loadCalendarEvents([]string{path}); len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	// Same size and modification time: the parsed events are reused
+	renamed := strings.Replace(testCalendar, "SUMMARY:Offsite", "SUMMARY:Outside", 1)
+	writeTestNotes(t, home, map[string]string{"work.ics": renamed})
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+	if events := loadCalendarEvents([]string{path}); events[1].Summary != "Offsite" {
+		t.Errorf("expected the cached events, got %q", events[1].Summary)
+	}
+
+	if err := os.Chtimes(path, time.Now(), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if events := loadCalendarEvents([]string{path}); events[1].Summary != "Outside" {
+		t.Errorf("expected the edited calendar to be parsed again, got %q", events[1].Summary)
+	}
+}
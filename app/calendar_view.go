@@ -1,50 +1,105 @@
 package app
 
 import (
+	"fmt"
 	"time"
+	"vision/ical"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
 type CalendarView struct {
 	startDate time.Time
+	weeks     int
+	weekends  bool
+	month     time.Month
+	cursor    time.Time
+	state     CalendarState
 	tasks     []Task
+	events    []ical.Occurrence
 	width     int
 	height    int
+	focused   bool
 }
 
 func NewCalendarView(tasks []Task, width, height int) CalendarView {
 	// Start from 3 weeks ago + current week, beginning of week
 	now := time.Now()
-	startDate := now.AddDate(0, 0, -21) // Go back 3 weeks
-	// Adjust to Monday of that week
-	for startDate.Weekday() != time.Monday {
-		startDate = startDate.AddDate(0, 0, -1)
-	}
+	startDate := mondayOf(now).AddDate(0, 0, -21)
 
 	return CalendarView{
 		startDate: startDate,
+		weeks:     calendarWeeks,
+		cursor:    startOfDay(now),
 		tasks:     tasks,
 		width:     width,
 		height:    height,
 	}
 }
 
+// WithState lays the calendar out around the state's cursor.
+func (cv CalendarView) WithState(state CalendarState) CalendarView {
+	cv.state = state
+	cv.cursor = state.CursorDay()
+	cv.startDate, cv.weeks = state.Grid()
+	cv.weekends = state.ShowWeekends
+	if state.MonthLayout {
+		cv.month = cv.cursor.Month()
+	}
+	return cv
+}
+
+// WithEvents adds the occurrences of events within the shown weeks.
+func (cv CalendarView) WithEvents(events []ical.Event) CalendarView {
+	cv.events = ical.Expand(events, cv.startDate, cv.startDate.AddDate(0, 0, 7*cv.weeks))
+	return cv
+}
+
+// WithFocus marks the calendar as taking the keys. Until then the sidebar
+// keeps them and the calendar shows how to focus it.
+func (cv CalendarView) WithFocus(focused bool) CalendarView {
+	cv.focused = focused
+	return cv
+}
+
 func (cv CalendarView) View() string {
-	header := cv.renderHeader()
-	grid := cv.renderGrid()
-	return lipgloss.JoinVertical(lipgloss.Left, header, grid)
+	sections := []string{}
+	if cv.month != 0 {
+		sections = append(sections, summaryTitleStyle(cv.width).Render(cv.cursor.Format("January 2006")))
+	}
+
+	sections = append(sections, cv.renderHeader(), cv.renderGrid())
+
+	if cv.state.Moving != nil {
+		sections = append(sections, "", scheduledTextStyle.Render("Moving "+truncateString(cv.state.Moving.Summary(), 40)+" · enter to drop on "+cv.cursor.Format("Mon 2 Jan")+", esc to cancel"))
+	} else if cv.state.DayOpen {
+		sections = append(sections, "", cv.renderDayTasks())
+	} else if !cv.focused {
+		sections = append(sections, "", loadingTextStyle.Render("f to move around the calendar"))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+func (cv CalendarView) columns() int {
+	if cv.weekends {
+		return 7
+	}
+	return 5
+}
+
+func (cv CalendarView) cellWidth() int {
+	return (cv.width - 10) / cv.columns() // Account for borders
 }
 
 func (cv CalendarView) renderHeader() string {
-	days := []string{"Mon", "Tue", "Wed", "Thu", "Fri"}
+	days := []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}[:cv.columns()]
 	var headers []string
-	cellWidth := (cv.width - 10) / 5 // Account for borders, 5 days only
 
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(summaryTitleColor).
-		Width(cellWidth).
+		Width(cv.cellWidth()).
 		Align(lipgloss.Center)
 
 	for _, day := range days {
@@ -57,38 +112,34 @@ func (cv CalendarView) renderHeader() string {
 func (cv CalendarView) renderGrid() string {
 	var weeks []string
 	currentDate := cv.startDate
-	today := time.Now()
 
-	cellWidth := (cv.width - 10) / 5
 	cellStyle := lipgloss.NewStyle().
-		Width(cellWidth).
+		Width(cv.cellWidth()).
 		Padding(0, 1).
 		Border(lipgloss.NormalBorder(), false, true, false, true)
 
-	for week := 0; week < 4; week++ {
+	for week := 0; week < cv.weeks; week++ {
 		var days []string
-		for day := 0; day < 5; day++ { // Only weekdays
-			if !currentDate.After(today) { // Only show until today
-				dayTasks := cv.getTasksForDate(currentDate)
-				dayContent := cv.renderDay(currentDate, dayTasks)
-
-				// Highlight current day
-				if currentDate.Format("2006-01-02") == today.Format("2006-01-02") {
-					cellStyle = cellStyle.BorderForeground(highlightedTextColor)
-				} else {
-					cellStyle = cellStyle.BorderForeground(lipgloss.Color("#9A9CCD"))
-				}
-
-				days = append(days, cellStyle.Render(dayContent))
+		for day := 0; day < cv.columns(); day++ {
+			dayTasks := cv.getTasksForDate(currentDate)
+			dayContent := cv.renderDay(currentDate, dayTasks)
+
+			// Highlight the cursor's day
+			if sameDay(currentDate, cv.cursor) {
+				cellStyle = cellStyle.BorderForeground(highlightedTextColor)
+			} else {
+				cellStyle = cellStyle.BorderForeground(lipgloss.Color("#9A9CCD"))
 			}
+
+			days = append(days, cellStyle.Render(dayContent))
 			currentDate = currentDate.AddDate(0, 0, 1)
 		}
 		// Skip weekend
-		currentDate = currentDate.AddDate(0, 0, 2)
+		currentDate = currentDate.AddDate(0, 0, 7-cv.columns())
 		weeks = append(weeks, lipgloss.JoinHorizontal(lipgloss.Top, days...))
 
 		// Add spacing between weeks if not the last week
-		if week < 3 {
+		if week < cv.weeks-1 {
 			weeks = append(weeks, "")
 		}
 	}
@@ -106,6 +157,11 @@ func (cv CalendarView) renderDay(date time.Time, tasks []Task) string {
 		Bold(true).
 		MarginBottom(1)
 
+	// Days of other months are only dates in the month layout
+	if cv.month != 0 && date.Month() != cv.month {
+		return dateStyle.Foreground(inactiveFileColor).Render(date.Format("2 Jan"))
+	}
+
 	// If it's today, use green color and append (Today)
 	if date.Format("2006-01-02") == today.Format("2006-01-02") {
 		dateStyle = dateStyle.Foreground(completedColor)
@@ -116,6 +172,10 @@ func (cv CalendarView) renderDay(date time.Time, tasks []Task) string {
 		content = append(content, dateStyle.Render(dateStr))
 	}
 
+	for _, event := range cv.getEventsForDate(date) {
+		content = append(content, eventTextStyle.Render(truncateString(formatOccurrence(event), 25)))
+	}
+
 	for _, task := range tasks {
 		status := task.StatusAtDate(date.Format("2006-01-02"))
 		shouldShow := false
@@ -147,13 +207,54 @@ func (cv CalendarView) renderDay(date time.Time, tasks []Task) string {
 	return lipgloss.JoinVertical(lipgloss.Left, content...)
 }
 
+// renderDayTasks lists the tasks of the cursor's day to pick one to move.
+func (cv CalendarView) renderDayTasks() string {
+	tasks := cv.getTasksForDate(cv.cursor)
+	lines := []string{summaryTitleStyle(cv.width).Render(fmt.Sprintf("%s (%d tasks)", cv.cursor.Format("Monday 2 January"), len(tasks)))}
+
+	if len(tasks) == 0 {
+		return joinVertical(lines[0], loadingTextStyle.Render("Nothing scheduled"))
+	}
+
+	for index, task := range tasks {
+		line := task.FileName + ": " + task.Summary()
+		if index == cv.state.TaskCursor {
+			lines = append(lines, highlightedTextStyle.Render("> "+line))
+		} else {
+			lines = append(lines, defaultTextStyle.Render("  "+line))
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
 func (cv CalendarView) getTasksForDate(date time.Time) []Task {
+	return tasksOnDate(cv.tasks, date)
+}
+
+func (cv CalendarView) getEventsForDate(date time.Time) []ical.Occurrence {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 0, 1)
+	var dayEvents []ical.Occurrence
+
+	for _, event := range cv.events {
+		if event.Start.Before(end) && (event.End.After(start) || event.Start.Equal(start)) {
+			dayEvents = append(dayEvents, event)
+		}
+	}
+
+	return dayEvents
+}
+
+// tasksOnDate returns the tasks with a status on date: scheduled, started,
+// overdue or completed.
+func tasksOnDate(tasks []Task, date time.Time) []Task {
 	dateStr := date.Format("2006-01-02")
 	var dayTasks []Task
 
-	for _, task := range cv.tasks {
-		status := task.StatusAtDate(dateStr)
-		if status != unscheduled {
+	for _, task := range tasks {
+		switch task.StatusAtDate(dateStr) {
+		case scheduled, started, overdue, completed:
 			dayTasks = append(dayTasks, task)
 		}
 	}
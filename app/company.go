@@ -1,13 +1,20 @@
 package app
 
-import "vision/config"
+import (
+	"slices"
+	"strings"
+	"vision/config"
+)
 
 type Company struct {
-	DisplayName    string   `json:"displayName"`
-	FolderPathName string   `json:"folderPathName"`
-	FullPath       string   `json:"fullPath"`
-	SubFolders     []string `json:"subFolders"`
-	Color          string   `json:"color"`
+	DisplayName    string        `json:"displayName"`
+	FolderPathName string        `json:"folderPathName"`
+	FullPath       string        `json:"fullPath"`
+	SubFolders     []string      `json:"subFolders"`
+	Color          string        `json:"color"`
+	Calendars      []string      `json:"calendars"`
+	ICSExport      string        `json:"icsExport"`
+	CalDAV         config.CalDAV `json:"caldav"`
 }
 
 func CreateCompanyFromConfigCompany(company config.Company) Company {
@@ -17,6 +24,9 @@ func CreateCompanyFromConfigCompany(company config.Company) Company {
 		FullPath:       company.FullPath,
 		SubFolders:     company.SubFolders,
 		Color:          company.Color,
+		Calendars:      company.Calendars,
+		ICSExport:      company.ICSExport,
+		CalDAV:         company.CalDAV,
 	}
 }
 
@@ -27,3 +37,14 @@ func CompaniesFromConfig(companies []config.Company) []Company {
 	}
 	return result
 }
+
+// companyNamed finds a company by display or folder name, ignoring case.
+func companyNamed(companies []Company, name string) (Company, bool) {
+	index := slices.IndexFunc(companies, func(company Company) bool {
+		return strings.EqualFold(company.FolderPathName, name) || strings.EqualFold(company.DisplayName, name)
+	})
+	if index == -1 {
+		return Company{}, false
+	}
+	return companies[index], true
+}
@@ -0,0 +1,260 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+)
+
+const (
+	// dashboardCardWidth is the width of a company card, borders excluded.
+	dashboardCardWidth = 32
+	// dashboardPriorityLimit caps the priority tasks a card lists.
+	dashboardPriorityLimit = 3
+)
+
+// DashboardState holds the company cards of the dashboard and the selected
+// one.
+type DashboardState struct {
+	Cards   []DashboardCard
+	Cursor  int
+	Loading bool
+}
+
+// DashboardCard summarises a company's tasks for the dashboard.
+type DashboardCard struct {
+	Company           Company
+	Started           int
+	Scheduled         int
+	Overdue           int
+	Priority          []Task
+	CompletedThisWeek int
+	LastStandup       string
+}
+
+// buildDashboardCard counts the company's tasks by their status today.
+func buildDashboardCard(company Company, tasks []Task, lastStandup string, today time.Time) DashboardCard {
+	card := DashboardCard{Company: company, LastStandup: lastStandup}
+	date := today.Format("2006-01-02")
+	monday := mondayOf(today).Format("2006-01-02")
+
+	for _, task := range tasks {
+		status := task.StatusAtDate(date)
+		switch status {
+		case started:
+			card.Started++
+		case scheduled:
+			card.Scheduled++
+		case overdue:
+			card.Overdue++
+		}
+
+		active := status == started || status == scheduled || status == overdue
+		if active && !task.Completed && strings.Contains(task.Text, strings.TrimSpace(PriorityIcon)) {
+			card.Priority = append(card.Priority, task)
+		}
+
+		if task.Completed && task.CompletedDate >= monday && task.CompletedDate <= date {
+			card.CompletedThisWeek++
+		}
+	}
+
+	return card
+}
+
+// lastStandup returns the date of the newest standup note in dir, named
+// after its day.
+func lastStandup(dir string, extension string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+
+	last := ""
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), extension)
+		if _, ok := parseDay(name); ok && !entry.IsDir() && name > last {
+			last = name
+		}
+	}
+	return last
+}
+
+// loadDashboard builds a card for each company from its task files and
+// standups.
+func loadDashboard(ctx context.Context, root string, companies []Company, index *FileIndex, extension string, today time.Time) []DashboardCard {
+	var tm TaskManager
+	var cards []DashboardCard
+	defer saveIndex(index)
+
+	for _, company := range companies {
+		files, err := readNotes(ctx, filepath.Join(root, company.FolderPathName, "tasks"), index, extension, false, nil)
+		if err != nil {
+			log.Warn("Failed to read tasks for the dashboard", "company", company.DisplayName, "error", err)
+		}
+
+		var tasks []Task
+		for _, file := range files {
+			tasks = append(tasks, tm.CreateTasks(company, file.File.Name, file.Tasks)...)
+		}
+
+		standup := lastStandup(filepath.Join(root, company.FolderPathName, "standups"), extension)
+		cards = append(cards, buildDashboardCard(company, tasks, standup, today))
+	}
+
+	return cards
+}
+
+// dashboardCmd loads the dashboard's cards in the background.
+func (m *Model) dashboardCmd() tea.Cmd {
+	companies := m.DirectoryManager.Companies
+	index := m.FileManager.Index
+	extension := m.FileManager.FileExtension
+	m.Dashboard.Loading = true
+
+	return func() tea.Msg {
+		return DashboardLoadedMsg{Cards: loadDashboard(context.Background(), notesPath(), companies, index, extension, time.Now())}
+	}
+}
+
+// DashboardControl handles the keys of the dashboard: moving between the
+// company cards, jumping into one and reloading them
+type DashboardControl struct{}
+
+// Handles reports whether the dashboard takes the key. It takes every key
+// while shown, so the ones it doesn't use never reach the view behind it.
+func (dc DashboardControl) Handles(key string, m *Model) bool {
+	return m.ViewManager.IsDashboardView
+}
+
+// HandleKey routes dashboard keys to their handlers
+func (dc DashboardControl) HandleKey(key string, m *Model) tea.Cmd {
+	ds := &m.Dashboard
+	columns := dashboardColumns(m.ViewManager.Width)
+
+	switch key {
+	case "l", "right", "tab":
+		goToNext(&ds.Cursor, len(ds.Cards))
+	case "h", "left", "shift+tab":
+		goToPrevious(&ds.Cursor)
+	case "j", "down":
+		if ds.Cursor+columns < len(ds.Cards) {
+			ds.Cursor += columns
+		}
+	case "k", "up":
+		if ds.Cursor-columns >= 0 {
+			ds.Cursor -= columns
+		}
+	case "enter":
+		return dc.Select(m)
+	case "0":
+		return m.dashboardCmd()
+	case "esc":
+		m.ViewManager.IsDashboardView = false
+	}
+
+	return nil
+}
+
+// Select jumps into the selected card's company
+func (dc DashboardControl) Select(m *Model) tea.Cmd {
+	if m.Dashboard.Cursor >= len(m.Dashboard.Cards) {
+		return nil
+	}
+
+	m.GoToCompany(strings.ToLower(m.Dashboard.Cards[m.Dashboard.Cursor].Company.DisplayName))
+	return nil
+}
+
+// ToggleDashboard opens the dashboard with fresh cards, or closes it
+func (dc DashboardControl) ToggleDashboard(m *Model) tea.Cmd {
+	m.ViewManager.IsDashboardView = !m.ViewManager.IsDashboardView
+	if m.ViewManager.IsDashboardView {
+		return m.dashboardCmd()
+	}
+	return nil
+}
+
+func dashboardColumns(width int) int {
+	return max(1, width/(dashboardCardWidth+4))
+}
+
+// renderDashboard lays the company cards out in rows that fit the width.
+func renderDashboard(m *Model) string {
+	ds := m.Dashboard
+	if len(ds.Cards) == 0 {
+		if ds.Loading {
+			return loadingTextStyle.Render(m.Spinner.View() + " Loading companies...")
+		}
+		return loadingTextStyle.Render("No companies configured")
+	}
+
+	columns := dashboardColumns(m.ViewManager.Width)
+	var rows []string
+	for start := 0; start < len(ds.Cards); start += columns {
+		var cards []string
+		for index := start; index < min(start+columns, len(ds.Cards)); index++ {
+			cards = append(cards, renderDashboardCard(ds.Cards[index], index == ds.Cursor))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cards...))
+	}
+
+	hint := loadingTextStyle.Render("enter to open a company · esc to close · 0 to reload")
+	return lipgloss.JoinVertical(lipgloss.Left, append(rows, hint)...)
+}
+
+func renderDashboardCard(card DashboardCard, selected bool) string {
+	color := lipgloss.Color(card.Company.Color)
+	title := lipgloss.NewStyle().Bold(true).Foreground(color).Render(card.Company.DisplayName)
+
+	count := func(label string, value int, style lipgloss.Style) string {
+		return lipgloss.NewStyle().Width(18).Render(label) + style.Render(fmt.Sprintf("%d", value))
+	}
+
+	standup := "none"
+	if day, ok := parseDay(card.LastStandup); ok {
+		standup = day.Format("Mon Jan 2")
+	}
+
+	lines := []string{
+		title,
+		"",
+		count("Started", card.Started, startedTextStyle),
+		count("Scheduled", card.Scheduled, scheduledTextStyle),
+		count("Overdue", card.Overdue, overdueTextStyle),
+		count("Done this week", card.CompletedThisWeek, completedTextStyle),
+		lipgloss.NewStyle().Width(18).Render("Last standup") + taskDateStyle().Render(standup),
+		"",
+		priorityTextStyle.Render("Priority today"),
+	}
+
+	if len(card.Priority) == 0 {
+		lines = append(lines, loadingTextStyle.Render("None"))
+	}
+	for index, task := range card.Priority {
+		if index == dashboardPriorityLimit {
+			lines = append(lines, loadingTextStyle.Render(fmt.Sprintf("and %d more", len(card.Priority)-index)))
+			break
+		}
+		lines = append(lines, defaultTextStyle.Render(truncateString(taskTitle(task), dashboardCardWidth-2)))
+	}
+
+	style := lipgloss.NewStyle().
+		Width(dashboardCardWidth).
+		Padding(0, 1).
+		Margin(0, 1, 1, 0).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(color)
+	if selected {
+		style = style.Border(lipgloss.ThickBorder()).BorderForeground(highlightedTextColor)
+	}
+
+	return style.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildDashboardCard(t *testing.T) {
+	today := day(2024, 3, 13) // Wednesday
+	tasks := []Task{
+		{Text: " Ship it 🛫 2024-03-12", StartDate: "2024-03-12", Started: true},
+		{Text: " 🔺 Fix login ⏳ 2024-03-13", ScheduledDate: "2024-03-13", Scheduled: true},
+		{Text: " 🔺 Later ⏳ 2024-03-20", ScheduledDate: "2024-03-20", Scheduled: true},
+		{Text: " Old ⏳ 2024-02-01", ScheduledDate: "2024-02-01", Scheduled: true},
+		{Text: " Done ✅ 2024-03-11", CompletedDate: "2024-03-11", Completed: true},
+		{Text: " Last week ✅ 2024-03-08", CompletedDate: "2024-03-08", Completed: true},
+	}
+
+	card := buildDashboardCard(Company{DisplayName: "Clerky"}, tasks, "2024-03-12", today)
+
+	if card.Started != 1 || card.Scheduled != 1 || card.Overdue != 1 {
+		t.Errorf("expected 1 started, scheduled and overdue task, got %+v", card)
+	}
+	if len(card.Priority) != 1 || taskTitle(card.Priority[0]) != "🔺 Fix login" {
+		t.Errorf("expected today's priority task, got %+v", card.Priority)
+	}
+	if card.CompletedThisWeek != 1 {
+		t.Errorf("expected 1 task completed this week, got %d", card.CompletedThisWeek)
+	}
+}
+
+func TestLoadDashboard(t *testing.T) {
+	root := t.TempDir()
+	writeTestNotes(t, filepath.Join(root, "clerky", "tasks"), map[string]string{
+		"Release.md": "# Release\n- [ ] Ship it 🛫 2024-03-12\n",
+	})
+	writeTestNotes(t, filepath.Join(root, "clerky", "standups"), map[string]string{
+		"2024-03-11.md": "",
+		"2024-03-12.md": "",
+		"template.md":   "",
+	})
+
+	companies := []Company{
+		{DisplayName: "Clerky", FolderPathName: "clerky", Color: "#4CD137"},
+		{DisplayName: "Qvest", FolderPathName: "qvest.us"},
+	}
+	index := OpenFileIndex(filepath.Join(t.TempDir(), "index.json"))
+
+	cards := loadDashboard(context.Background(), root, companies, index, ".md", day(2024, 3, 13))
+
+	if len(cards) != 2 {
+		t.Fatalf("expected a card for each company, got %+v", cards)
+	}
+	if cards[0].Started != 1 || cards[0].LastStandup != "2024-03-12" {
+		t.Errorf("expected Clerky's started task and last standup, got %+v", cards[0])
+	}
+	if cards[1].Started != 0 || cards[1].LastStandup != "" {
+		t.Errorf("expected an empty card for a company without notes, got %+v", cards[1])
+	}
+}
+
+func TestDashboardSelectsCompany(t *testing.T) {
+	m := &Model{
+		DirectoryManager: DirectoryManager{Companies: []Company{{DisplayName: "Clerky"}, {DisplayName: "Qvest"}}},
+		ViewManager:      ViewManager{CurrentView: CategoriesView, IsDashboardView: true, Width: 80},
+		FileManager:      FileManager{FileCache: NewLRU[string, []FileInfo](2), TaskCache: NewLRU[string, map[string][]Task](2)},
+		Dashboard:        DashboardState{Cards: []DashboardCard{{Company: Company{DisplayName: "Clerky"}}, {Company: Company{DisplayName: "Qvest"}}}},
+	}
+
+	for _, key := range []string{"l", "enter"} {
+		if !(DashboardControl{}).Handles(key, m) {
+			t.Fatalf("expected the dashboard to handle %q", key)
+		}
+		DashboardControl{}.HandleKey(key, m)
+	}
+
+	if m.ViewManager.IsDashboardView || m.DirectoryManager.SelectedCompany.DisplayName != "Qvest" {
+		t.Errorf("expected to jump into Qvest, got %q with the dashboard open: %v", m.DirectoryManager.SelectedCompany.DisplayName, m.ViewManager.IsDashboardView)
+	}
+
+	if view := renderDashboardCard(m.Dashboard.Cards[0], true); !strings.Contains(view, "Clerky") || !strings.Contains(view, "none") {
+		t.Errorf("expected the card to show the company without standups, got\n%s", view)
+	}
+}
+
+func TestDashboardKeepsKeysFromTheHiddenView(t *testing.T) {
+	m := &Model{
+		ViewManager: ViewManager{CurrentView: CategoriesView, IsDashboardView: true, Width: 80},
+		Dashboard:   DashboardState{Cards: []DashboardCard{{Company: Company{DisplayName: "Clerky"}}}},
+	}
+
+	for _, key := range []string{"/", "0"} {
+		if !(DashboardControl{}).Handles(key, m) {
+			t.Fatalf("expected the dashboard to take %q", key)
+		}
+	}
+
+	if (DashboardControl{}).HandleKey("/", m) != nil || m.IsFilterView() || !m.ViewManager.IsDashboardView {
+		t.Errorf("expected / to be ignored while the dashboard is shown")
+	}
+
+	if cmd := (DashboardControl{}).HandleKey("0", m); cmd == nil || !m.ViewManager.IsDashboardView || !m.Dashboard.Loading {
+		t.Errorf("expected 0 to reload the dashboard without closing it")
+	}
+}
@@ -3,12 +3,14 @@ package app
 import "strings"
 
 type DirectoryManager struct {
-	Companies        []Company
-	Categories       []string
-	SelectedCompany  Company
-	SelectedCategory string
-	CompaniesCursor  int
-	CategoriesCursor int
+	Companies         []Company
+	Categories        []string
+	SavedViews        []SavedView
+	SelectedCompany   Company
+	SelectedCategory  string
+	SelectedSavedView string
+	CompaniesCursor   int
+	CategoriesCursor  int
 }
 
 func (dm *DirectoryManager) CurrentFolderPath() string {
@@ -27,8 +29,68 @@ func (dm *DirectoryManager) AssignCompany() {
 	dm.SelectedCompany = dm.Companies[dm.CompaniesCursor]
 }
 
+// CategoryNames lists the category folders followed by the saved views of
+// the selected company, in sidebar order.
+func (dm *DirectoryManager) CategoryNames() []string {
+	names := append([]string{}, dm.Categories...)
+	for _, view := range dm.CompanySavedViews() {
+		names = append(names, view.Label())
+	}
+	return names
+}
+
+func (dm *DirectoryManager) CompanySavedViews() []SavedView {
+	var views []SavedView
+	for _, view := range dm.SavedViews {
+		if view.AppliesTo(dm.SelectedCompany) {
+			views = append(views, view)
+		}
+	}
+	return views
+}
+
+// SavedViewAtCursor returns the saved view under the category cursor, if the
+// cursor is past the category folders.
+func (dm *DirectoryManager) SavedViewAtCursor() (SavedView, bool) {
+	index := dm.CategoriesCursor - len(dm.Categories)
+	views := dm.CompanySavedViews()
+
+	if index < 0 || index >= len(views) {
+		return SavedView{}, false
+	}
+
+	return views[index], true
+}
+
+// CurrentSavedView returns the selected saved view while it applies to the
+// selected company.
+func (dm *DirectoryManager) CurrentSavedView() (SavedView, bool) {
+	if dm.SelectedSavedView == "" {
+		return SavedView{}, false
+	}
+
+	for _, view := range dm.CompanySavedViews() {
+		if view.Name == dm.SelectedSavedView {
+			return view, true
+		}
+	}
+
+	return SavedView{}, false
+}
+
 func (dm *DirectoryManager) AssignCategory() {
+	if names := dm.CategoryNames(); dm.CategoriesCursor >= len(names) {
+		dm.CategoriesCursor = len(names) - 1
+	}
+
+	if view, ok := dm.SavedViewAtCursor(); ok {
+		dm.SelectedCategory = "tasks"
+		dm.SelectedSavedView = view.Name
+		return
+	}
+
 	dm.SelectedCategory = dm.Categories[dm.CategoriesCursor]
+	dm.SelectedSavedView = ""
 }
 
 func (dm *DirectoryManager) SelectCompany(companyName string) bool {
@@ -47,6 +109,7 @@ func (dm *DirectoryManager) SelectCategory(categoryName string) bool {
 	for _, category := range dm.Categories {
 		if strings.ToLower(category) == categoryName {
 			dm.SelectedCategory = categoryName
+			dm.SelectedSavedView = ""
 			return true
 		}
 	}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"maps"
+	"os"
+	"strings"
+	"time"
+)
+
+// fileContent is a cached note body, valid while the note is unchanged.
+type fileContent struct {
+	UpdatedAt time.Time
+	Content   string
+}
+
+// FileContent returns the cached content of a listed file. Lists only carry
+// metadata; content is loaded on demand for the details view.
+func (fm *FileManager) FileContent(file FileInfo) (string, bool) {
+	if file.Content != "" {
+		return file.Content, true
+	}
+
+	cached, ok := fm.ContentCache.Get(file.FullPath)
+	if !ok || !cached.UpdatedAt.Equal(file.UpdatedAt) {
+		return "", false
+	}
+
+	return cached.Content, true
+}
+
+func (fm *FileManager) StoreContent(file FileInfo, content string) {
+	fm.ContentCache.Put(file.FullPath, fileContent{UpdatedAt: file.UpdatedAt, Content: content})
+	delete(fm.contentRequested, file.FullPath)
+}
+
+// requestContent reports whether the file's content still has to be loaded,
+// marking it as requested so it is loaded only once.
+func (fm *FileManager) requestContent(file FileInfo) bool {
+	if _, ok := fm.FileContent(file); ok || fm.contentRequested[file.FullPath] {
+		return false
+	}
+
+	if fm.contentRequested == nil {
+		fm.contentRequested = make(map[string]bool)
+	}
+
+	fm.contentRequested[file.FullPath] = true
+	return true
+}
+
+// readFileContent reads a note's body without its YAML frontmatter.
+func readFileContent(file FileInfo) (string, error) {
+	content, err := os.ReadFile(file.FullPath)
+	if err != nil {
+		return "", err
+	}
+
+	return removeYAMLFrontmatter(string(content)), nil
+}
+
+// cacheContents moves the content of loaded files into the content cache so
+// the listed files only keep their metadata.
+func (fm *FileManager) cacheContents(files []FileInfo) []FileInfo {
+	stripped := make([]FileInfo, len(files))
+
+	for index, file := range files {
+		if file.Content != "" {
+			fm.StoreContent(file, file.Content)
+			file.Content = ""
+		}
+		stripped[index] = file
+	}
+
+	return stripped
+}
+
+func fileCacheKey(dm *DirectoryManager) string {
+	return dm.CurrentFolderPath() + "/" + strings.ToLower(dm.SelectedCategory) + "/" + dm.SelectedSavedView
+}
+
+// showCachedFiles lists the files last loaded for the selected company and
+// category, if any, while the refreshed list loads.
+func (fm *FileManager) showCachedFiles(dm *DirectoryManager) {
+	fm.Files, _ = fm.FileCache.Get(fileCacheKey(dm))
+}
+
+// restoreCachedTasks brings back the tasks last loaded for the selected
+// company while its task files are re-read.
+func (fm *FileManager) restoreCachedTasks(dm *DirectoryManager, tm *TaskManager) {
+	tasksByFile, ok := fm.TaskCache.Get(dm.CurrentFolderPath())
+	if !ok {
+		return
+	}
+
+	for filename, tasks := range tasksByFile {
+		tm.TaskCollection.Add(filename, tasks)
+	}
+}
+
+func (fm *FileManager) cacheTasks(dm *DirectoryManager, tm *TaskManager) {
+	fm.TaskCache.Put(dm.CurrentFolderPath(), maps.Clone(tm.TaskCollection.TasksByFile))
+}
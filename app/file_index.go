@@ -0,0 +1,223 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"vision/utils"
+
+	"github.com/charmbracelet/log"
+)
+
+const fileIndexVersion = 2
+
+// IndexEntry is the parsed metadata of one note. It is reused for as long as
+// the file's modification time and size are unchanged.
+type IndexEntry struct {
+	ModTime     int64            `json:"modTime"`
+	Size        int64            `json:"size"`
+	DisplayName string           `json:"displayName,omitempty"`
+	Tags        []string         `json:"tags,omitempty"`
+	Tasks       []utils.FileTask `json:"tasks,omitempty"`
+	Links       []string         `json:"links,omitempty"`
+}
+
+// FileIndex is the on-disk cache of parsed notes, keyed by full path. A nil
+// index is valid and parses every file.
+type FileIndex struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]IndexEntry
+	dirty   bool
+}
+
+type fileIndexData struct {
+	Version int                   `json:"version"`
+	Files   map[string]IndexEntry `json:"files"`
+}
+
+// IndexedFile is a note's metadata, tasks and the notes it links to,
+// without its content.
+type IndexedFile struct {
+	File  FileInfo
+	Tasks []utils.FileTask
+	Links []string
+}
+
+func fileIndexPath() string {
+	return filepath.Join(notesPath(), ".vision", "index.json")
+}
+
+// OpenFileIndex loads the index stored at path. A missing, unreadable or
+// outdated index starts empty and is rebuilt as files are read.
+func OpenFileIndex(path string) *FileIndex {
+	index := &FileIndex{path: path, entries: make(map[string]IndexEntry)}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warn("Failed to read file index", "path", path, "error", err)
+		}
+		return index
+	}
+
+	var data fileIndexData
+	if err := json.Unmarshal(content, &data); err != nil || data.Version != fileIndexVersion {
+		log.Warn("Ignoring outdated file index", "path", path, "error", err)
+		return index
+	}
+
+	if data.Files != nil {
+		index.entries = data.Files
+	}
+
+	return index
+}
+
+// Lookup returns the entry for a file if it was indexed with the same
+// modification time and size.
+func (fi *FileIndex) Lookup(fullPath string, info fs.FileInfo) (IndexEntry, bool) {
+	if fi == nil {
+		return IndexEntry{}, false
+	}
+
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+
+	entry, ok := fi.entries[fullPath]
+	if !ok || entry.ModTime != info.ModTime().UnixNano() || entry.Size != info.Size() {
+		return IndexEntry{}, false
+	}
+
+	return entry, true
+}
+
+func (fi *FileIndex) Store(fullPath string, info fs.FileInfo, entry IndexEntry) {
+	if fi == nil {
+		return
+	}
+
+	entry.ModTime = info.ModTime().UnixNano()
+	entry.Size = info.Size()
+
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+
+	fi.entries[fullPath] = entry
+	fi.dirty = true
+}
+
+// Prune drops the entries of files in dir that are no longer there.
+func (fi *FileIndex) Prune(dir string, present map[string]bool) {
+	if fi == nil {
+		return
+	}
+
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+
+	for fullPath := range fi.entries {
+		if filepath.Dir(fullPath) == dir && !present[fullPath] {
+			delete(fi.entries, fullPath)
+			fi.dirty = true
+		}
+	}
+}
+
+func (fi *FileIndex) Len() int {
+	if fi == nil {
+		return 0
+	}
+
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+
+	return len(fi.entries)
+}
+
+// Save writes the index if it changed since it was loaded or last saved. The
+// file is replaced atomically so a crash never leaves a partial index.
+func (fi *FileIndex) Save() error {
+	if fi == nil {
+		return nil
+	}
+
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+
+	if !fi.dirty {
+		return nil
+	}
+
+	content, err := json.Marshal(fileIndexData{Version: fileIndexVersion, Files: fi.entries})
+	if err != nil {
+		return fmt.Errorf("could not encode file index: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fi.path), 0755); err != nil {
+		return fmt.Errorf("could not create index folder: %w", err)
+	}
+
+	tmpPath := fi.path + ".tmp"
+	if err := os.WriteFile(tmpPath, content, 0644); err != nil {
+		return fmt.Errorf("could not write file index: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, fi.path); err != nil {
+		return fmt.Errorf("could not replace file index: %w", err)
+	}
+
+	fi.dirty = false
+	return nil
+}
+
+// Rebuild discards the index and re-parses every note in the companies'
+// category folders under root.
+func (fi *FileIndex) Rebuild(root string, companies []Company, extension string) (int, error) {
+	fi.mu.Lock()
+	fi.entries = make(map[string]IndexEntry)
+	fi.dirty = true
+	fi.mu.Unlock()
+
+	for _, company := range companies {
+		for _, category := range company.SubFolders {
+			path := filepath.Join(root, company.FolderPathName, strings.ToLower(category))
+			readNotes(context.Background(), path, fi, extension, false, nil)
+		}
+	}
+
+	return fi.Len(), fi.Save()
+}
+
+// indexNote parses the metadata, tasks and note links of a note's raw content.
+func indexNote(content string) IndexEntry {
+	body := removeYAMLFrontmatter(content)
+
+	var links []string
+	for _, link := range extractLinks(body) {
+		if link.IsNote() {
+			links = append(links, link.Target)
+		}
+	}
+
+	return IndexEntry{
+		DisplayName: extractTitleFromYAML(content),
+		Tags:        extractTagsFromYAML(content),
+		Tasks:       utils.ExtractTasksFromText(body),
+		Links:       links,
+	}
+}
+
+// readFileMetadataInDirectory lists the notes in path with their titles, tags
+// and tasks but without content. Only files changed since they were indexed
+// are read and parsed.
+func readFileMetadataInDirectory(path string, index *FileIndex, extension string) []IndexedFile {
+	files, _ := readNotes(context.Background(), path, index, extension, false, nil)
+	saveIndex(index)
+	return files
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReadFileMetadataInDirectoryUsesIndex(t *testing.T) {
+	dir := t.TempDir()
+	indexPath := filepath.Join(dir, ".vision", "index.json")
+	notePath := filepath.Join(dir, "onboarding.md")
+	modTime := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	writeNote := func(content string) {
+		if err := os.WriteFile(notePath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(notePath, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeNote("---\ntitle: Onboarding\ntags: [client]\n---\n- [ ] Send contract\n")
+	files := readFileMetadataInDirectory(dir, OpenFileIndex(indexPath), ".md")
+
+	if len(files) != 1 || files[0].File.DisplayName != "Onboarding" || !reflect.DeepEqual(files[0].File.Tags, []string{"client"}) {
+		t.Fatalf("expected the parsed onboarding note, got %+v", files)
+	}
+
+	if len(files[0].Tasks) != 1 || files[0].Tasks[0].Text != " Send contract" {
+		t.Fatalf("expected one task, got %+v", files[0].Tasks)
+	}
+
+	// Same size and modification time: the stored entry is reused.
+	writeNote("---\ntitle: Inboarding\ntags: [client]\n---\n- [ ] Send contract\n")
+	files = readFileMetadataInDirectory(dir, OpenFileIndex(indexPath), ".md")
+
+	if files[0].File.DisplayName != "Onboarding" {
+		t.Errorf("expected the indexed title, got %q", files[0].File.DisplayName)
+	}
+
+	// A changed size invalidates the entry.
+	writeNote("---\ntitle: Offboarding team\ntags: [client]\n---\n- [ ] Send contract\n")
+	files = readFileMetadataInDirectory(dir, OpenFileIndex(indexPath), ".md")
+
+	if files[0].File.DisplayName != "Offboarding team" {
+		t.Errorf("expected the file to be re-parsed, got %q", files[0].File.DisplayName)
+	}
+
+	if err := os.Remove(notePath); err != nil {
+		t.Fatal(err)
+	}
+
+	readFileMetadataInDirectory(dir, OpenFileIndex(indexPath), ".md")
+
+	if count := OpenFileIndex(indexPath).Len(); count != 0 {
+		t.Errorf("expected removed files to be pruned, got %d entries", count)
+	}
+}
+
+func TestFileIndexRebuild(t *testing.T) {
+	root := t.TempDir()
+	indexPath := filepath.Join(root, ".vision", "index.json")
+	company := Company{DisplayName: "Clerky", FolderPathName: "clerky", SubFolders: []string{"Tasks", "people"}}
+
+	for _, name := range []string{"clerky/tasks/a.md", "clerky/tasks/b.md", "clerky/people/Jane Doe.md", "clerky/other/ignored.md"} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("- [ ] "+name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	count, err := OpenFileIndex(indexPath).Rebuild(root, []Company{company}, ".md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 3 {
+		t.Errorf("expected 3 indexed notes, got %d", count)
+	}
+
+	if count := OpenFileIndex(indexPath).Len(); count != 3 {
+		t.Errorf("expected the rebuilt index to be saved, got %d entries", count)
+	}
+}
+
+func TestOpenFileIndexIgnoresOutdatedIndex(t *testing.T) {
+	indexPath := filepath.Join(t.TempDir(), "index.json")
+	if err := os.WriteFile(indexPath, []byte(`{"version":0,"files":{"/a.md":{}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := OpenFileIndex(indexPath).Len(); count != 0 {
+		t.Errorf("expected an empty index, got %d entries", count)
+	}
+}
@@ -8,6 +8,7 @@ type FileInfo struct {
 	UpdatedAt   time.Time
 	FullPath    string
 	DisplayName string
+	Tags        []string
 }
 
 func (f *FileInfo) FileNameWithoutExtension(extension string) string {
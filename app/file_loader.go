@@ -0,0 +1,331 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// fileLoadWorkers bounds how many notes are read from disk at once.
+const fileLoadWorkers = 8
+
+// loadProgress counts the notes read by a load so the sidebar can show how
+// far along it is. It is shared with the loading goroutines.
+type loadProgress struct {
+	total atomic.Int32
+	done  atomic.Int32
+}
+
+func (p *loadProgress) add(count int) {
+	if p != nil {
+		p.total.Add(int32(count))
+	}
+}
+
+func (p *loadProgress) increment() {
+	if p != nil {
+		p.done.Add(1)
+	}
+}
+
+func (p *loadProgress) Counts() (int, int) {
+	if p == nil {
+		return 0, 0
+	}
+	return int(p.done.Load()), int(p.total.Load())
+}
+
+// fileLoad is a snapshot of what to load, taken on the UI thread so the
+// background load never reads the model.
+type fileLoad struct {
+	ctx        context.Context
+	generation int
+	company    Company
+	companies  []Company
+	category   string
+	extension  string
+	index      *FileIndex
+	progress   *loadProgress
+
+	// withContent is set when the files are filtered by their content
+	withContent bool
+}
+
+// RequestRefresh marks the file list as stale. Update starts a background
+// load once the current message has been handled.
+func (fm *FileManager) RequestRefresh() {
+	fm.refreshRequested = true
+}
+
+func (fm *FileManager) IsLoading() bool {
+	return fm.loading != nil
+}
+
+func (fm *FileManager) LoadProgress() (int, int) {
+	return fm.loading.Counts()
+}
+
+// startLoad cancels the load in flight, if any, and prepares the next one.
+// Only the result of the latest load is applied.
+func (fm *FileManager) startLoad(dm *DirectoryManager) fileLoad {
+	if fm.cancelLoad != nil {
+		fm.cancelLoad()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	fm.cancelLoad = cancel
+	fm.loadGeneration++
+	fm.loading = &loadProgress{}
+	fm.refreshRequested = false
+	filter := fm.FileFilter
+
+	return fileLoad{
+		ctx:        ctx,
+		generation: fm.loadGeneration,
+		company:    dm.SelectedCompany,
+		companies:  dm.Companies,
+		category:   strings.ToLower(dm.SelectedCategory),
+		extension:  fm.FileExtension,
+		index:      fm.Index,
+		progress:   fm.loading,
+		withContent: filter.Query != "" && filter.Company == dm.CurrentCompanyName() &&
+			filter.Category == dm.SelectedCategory,
+	}
+}
+
+// loadFiles reads the notes of the load's category, the company's task
+// files and its calendars, and regenerates the companies' ICS exports. It
+// creates today's standup when the standups folder lacks one.
+func loadFiles(load fileLoad) FilesRefreshedMsg {
+	msg := FilesRefreshedMsg{Generation: load.generation, Company: load.company, Category: load.category}
+	path := filepath.Join(notesPath(), load.company.FolderPathName, load.category)
+	defer saveIndex(load.index)
+	log.Info("Fetching files", "path", path)
+
+	files, err := readNotes(load.ctx, path, load.index, load.extension, load.withContent, load.progress)
+	if err != nil {
+		msg.Err = err
+		return msg
+	}
+
+	if load.category == "standups" && len(files) > 0 {
+		slices.SortFunc(files, func(a, b IndexedFile) int { return nameCmp(a.File, b.File) })
+		lastStandup := files[0] // The first one is the most recent
+		today := time.Now().Format("2006-01-02") + load.extension
+
+		if lastStandup.File.Name != today && isWorkingDay() {
+			fm := FileManager{FileExtension: load.extension}
+			if err := fm.CreateStandup(load.company.FolderPathName); err != nil {
+				log.Warn("Failed to create standup", "error", err)
+			} else if files, err = readNotes(load.ctx, path, load.index, load.extension, load.withContent, load.progress); err != nil {
+				msg.Err = err
+				return msg
+			}
+		}
+	}
+
+	taskPath := filepath.Join(notesPath(), load.company.FolderPathName, "tasks")
+	msg.TaskFiles, err = readNotes(load.ctx, taskPath, load.index, load.extension, false, load.progress)
+	if err != nil {
+		msg.Err = err
+		return msg
+	}
+
+	writeICSExports(load.ctx, notesPath(), load.companies, load.company, msg.TaskFiles, load.index, load.extension)
+
+	msg.Notes = files
+	for _, file := range files {
+		msg.Files = append(msg.Files, file.File)
+	}
+
+	msg.Events = loadCalendarEvents(load.company.Calendars)
+
+	log.Info("Files count: " + fmt.Sprintf("%d", len(msg.Files)))
+	return msg
+}
+
+// loadLinkGraph builds the link graph of every company. It is only built
+// once: the loads then replace the links of the folders they read. It does
+// not use the load's context, so a superseded load doesn't cancel it.
+func loadLinkGraph(load fileLoad) LinkGraphBuiltMsg {
+	defer saveIndex(load.index)
+	graph, err := buildLinkGraph(context.Background(), notesPath(), load.companies, load.index, load.extension)
+	return LinkGraphBuiltMsg{Graph: graph, Err: err}
+}
+
+// ApplyLoadedFiles stores the result of a load. It reports false for
+// results of loads that were cancelled or superseded.
+func (fm *FileManager) ApplyLoadedFiles(msg FilesRefreshedMsg, dm *DirectoryManager, tm *TaskManager) bool {
+	if msg.Generation != fm.loadGeneration {
+		return false
+	}
+
+	fm.loading = nil
+
+	if msg.Err != nil {
+		log.Warn("Failed to load files", "error", msg.Err)
+		return false
+	}
+
+	fm.LinkGraph.Replace(msg.Company, msg.Category, msg.Notes, fm.FileExtension)
+	if msg.Category != "tasks" {
+		fm.LinkGraph.Replace(msg.Company, "tasks", msg.TaskFiles, fm.FileExtension)
+	}
+
+	for _, file := range msg.TaskFiles {
+		tasks := tm.CreateTasks(msg.Company, file.File.Name, file.Tasks)
+		tm.TaskCollection.Add(file.File.Name, tasks)
+		tm.TaskCollection.AddFileTags(file.File.Name, file.File.Tags)
+	}
+
+	files := slices.Clone(msg.Files)
+	if msg.Category == "tasks" {
+		files = sortedFiles(files, tm)
+	} else {
+		slices.SortFunc(files, nameCmp)
+	}
+
+	fm.Files = fm.cacheContents(fm.filterFiles(files, dm, tm))
+	fm.FileCache.Put(fileCacheKey(dm), fm.Files)
+	fm.cacheTasks(dm, tm)
+
+	if fm.PendingSelection != "" {
+		for index, file := range fm.Files {
+			if file.Name == fm.PendingSelection {
+				fm.FilesCursor = index
+				fm.SelectedFile = file
+			}
+		}
+		fm.PendingSelection = ""
+	}
+
+	if fm.FilesCursor >= len(fm.Files) {
+		fm.FilesCursor = max(len(fm.Files)-1, 0)
+	}
+
+	return true
+}
+
+// readNotes reads the notes in path with a bounded pool of workers. Titles,
+// tags and tasks come from the index for files unchanged since they were
+// indexed. Content is only read when withContent is set or the file changed.
+// The caller saves the index once it has read all the folders it needs.
+func readNotes(ctx context.Context, path string, index *FileIndex, extension string, withContent bool, progress *loadProgress) ([]IndexedFile, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		log.Warn("Failed to read directory", "path", path, "error", err)
+		return nil, nil // An empty folder instead of crashing
+	}
+
+	var notes []os.DirEntry
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), extension) || strings.HasPrefix(entry.Name(), "sortspec") {
+			continue
+		}
+		notes = append(notes, entry)
+	}
+
+	progress.add(len(notes))
+
+	results := make([]IndexedFile, len(notes))
+	read := make([]bool, len(notes))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < min(fileLoadWorkers, len(notes)); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for position := range jobs {
+				results[position], read[position] = readNote(path, notes[position], index, withContent)
+				progress.increment()
+			}
+		}()
+	}
+
+feed:
+	for position := range notes {
+		select {
+		case <-ctx.Done():
+			break feed
+		case jobs <- position:
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var files []IndexedFile
+	present := make(map[string]bool)
+
+	for position, file := range results {
+		if read[position] {
+			files = append(files, file)
+			present[file.File.FullPath] = true
+		}
+	}
+
+	index.Prune(path, present)
+
+	return files, nil
+}
+
+// saveIndex writes the index once a load has read all of its folders.
+func saveIndex(index *FileIndex) {
+	if err := index.Save(); err != nil {
+		log.Warn("Failed to save file index", "error", err)
+	}
+}
+
+func readNote(path string, entry os.DirEntry, index *FileIndex, withContent bool) (IndexedFile, bool) {
+	fullPath := filepath.Join(path, entry.Name())
+
+	info, err := entry.Info()
+	if err != nil {
+		log.Warn("Failed to get file info", "path", fullPath, "error", err)
+		return IndexedFile{}, false
+	}
+
+	indexed, found := index.Lookup(fullPath, info)
+
+	content := ""
+	if withContent || !found {
+		raw, err := os.ReadFile(fullPath)
+		if err != nil {
+			log.Warn("Failed to read file", "path", fullPath, "error", err)
+			return IndexedFile{}, false
+		}
+		content = string(raw)
+	}
+
+	if !found {
+		indexed = indexNote(content)
+		index.Store(fullPath, info, indexed)
+	}
+
+	file := FileInfo{
+		Name:        entry.Name(),
+		DisplayName: indexed.DisplayName,
+		UpdatedAt:   info.ModTime(),
+		FullPath:    fullPath,
+		Tags:        indexed.Tags,
+	}
+
+	if withContent {
+		file.Content = removeYAMLFrontmatter(content)
+	}
+
+	return IndexedFile{File: file, Tasks: indexed.Tasks, Links: indexed.Links}, true
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestNotes(t *testing.T, dir string, notes map[string]string) {
+	t.Helper()
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range notes {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFileManagerAppliesOnlyTheLatestLoad(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	clerky := Company{DisplayName: "Clerky", FolderPathName: "clerky", SubFolders: []string{"tasks", "meetings"}}
+	qvest := Company{DisplayName: "Qvest.US", FolderPathName: "qvest_us", SubFolders: []string{"tasks", "meetings"}}
+	writeTestNotes(t, filepath.Join(home, "Notes", "clerky", "meetings"), map[string]string{"clerky sync.md": "notes"})
+	writeTestNotes(t, filepath.Join(home, "Notes", "clerky", "tasks"), map[string]string{"billing.md": "- [ ] Fix invoices"})
+	writeTestNotes(t, filepath.Join(home, "Notes", "qvest_us", "meetings"), map[string]string{"qvest sync.md": "notes"})
+	writeTestNotes(t, filepath.Join(home, "Notes", "qvest_us", "tasks"), map[string]string{"ops.md": "- [ ] Rotate keys"})
+
+	dm := DirectoryManager{Companies: []Company{clerky, qvest}, Categories: []string{"tasks", "meetings"}, SelectedCompany: clerky, SelectedCategory: "meetings"}
+	tm := TaskManager{TaskCollection: TaskCollection{TasksByFile: make(map[string][]Task)}, FileExtension: ".md"}
+	fm := FileManager{FileExtension: ".md"}
+
+	stale := fm.startLoad(&dm)
+	dm.SelectedCompany = qvest
+	latest := fm.startLoad(&dm)
+
+	if stale.ctx.Err() == nil {
+		t.Errorf("expected the superseded load to be cancelled")
+	}
+
+	if fm.ApplyLoadedFiles(loadFiles(stale), &dm, &tm) {
+		t.Errorf("expected the superseded load to be dropped")
+	}
+
+	if !fm.IsLoading() {
+		t.Errorf("expected the latest load to still be in flight")
+	}
+
+	if !fm.ApplyLoadedFiles(loadFiles(latest), &dm, &tm) {
+		t.Fatalf("expected the latest load to be applied")
+	}
+
+	if len(fm.Files) != 1 || fm.Files[0].Name != "qvest sync.md" {
+		t.Errorf("expected the Qvest.US meetings, got %v", fm.Files)
+	}
+
+	if tasks := tm.TaskCollection.TasksByFile["ops.md"]; len(tasks) != 1 || tasks[0].Company != "qvest_us" || tasks[0].CompanyName != "Qvest.US" {
+		t.Errorf("expected the Qvest.US tasks to be keyed by the company folder, got %+v", tasks)
+	}
+
+	if fm.IsLoading() {
+		t.Errorf("expected loading to be done")
+	}
+}
+
+func TestReadNotesWithWorkerPool(t *testing.T) {
+	dir := t.TempDir()
+	notes := map[string]string{"sortspec.md": "ignored", "readme.txt": "ignored"}
+	for i := 0; i < 3*fileLoadWorkers; i++ {
+		notes[fmt.Sprintf("note-%02d.md", i)] = fmt.Sprintf("- [ ] Task %d", i)
+	}
+	writeTestNotes(t, dir, notes)
+
+	progress := &loadProgress{}
+	files, err := readNotes(context.Background(), dir, nil, ".md", true, progress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 3*fileLoadWorkers {
+		t.Fatalf("expected %d notes, got %d", 3*fileLoadWorkers, len(files))
+	}
+
+	if files[5].File.Name != "note-05.md" || files[5].File.Content != "- [ ] Task 5" || len(files[5].Tasks) != 1 {
+		t.Errorf("expected notes in directory order with content and tasks, got %+v", files[5])
+	}
+
+	if done, total := progress.Counts(); done != total || total != 3*fileLoadWorkers {
+		t.Errorf("expected %d/%d progress, got %d/%d", total, total, done, total)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := readNotes(ctx, dir, nil, ".md", true, nil); err == nil {
+		t.Errorf("expected a cancelled read to fail")
+	}
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -11,6 +12,7 @@ import (
 	"strings"
 	"time"
 	"vision/mindmap"
+	"vision/utils"
 
 	"github.com/charmbracelet/log"
 )
@@ -18,66 +20,82 @@ import (
 type FileManager struct {
 	FilesCursor            int
 	Files                  []FileInfo
-	FileCache              map[string][]FileInfo
-	TaskCache              map[string]map[string][]Task
+	FileCache              *LRU[string, []FileInfo]
+	TaskCache              *LRU[string, map[string][]Task]
+	ContentCache           *LRU[string, fileContent]
 	SelectedFile           FileInfo
 	TaskSuggestions        []string
 	PeopleSuggestions      []string
 	SuggestionsFilterValue string
+	FileFilter             FileFilter
 	FileExtension          string
+	Index                  *FileIndex
+	LinkGraph              *LinkGraph
+	PendingSelection       string
 	Updater                mindmap.MindMapUpdaterInterface
+	refreshRequested       bool
+	linkGraphRequested     bool
+	loading                *loadProgress
+	cancelLoad             context.CancelFunc
+	loadGeneration         int
+	contentRequested       map[string]bool
 }
 
 func NewFileManager() *FileManager {
 	return &FileManager{
-		FileCache:     make(map[string][]FileInfo),
-		TaskCache:     make(map[string]map[string][]Task),
+		FileCache:     NewLRU[string, []FileInfo](defaultCacheSize),
+		TaskCache:     NewLRU[string, map[string][]Task](defaultCacheSize),
+		ContentCache:  NewLRU[string, fileContent](defaultCacheSize),
 		FileExtension: ".md",
 		Updater:       mindmap.NewNullUpdater(),
 	}
 }
 
+// FetchFiles loads the selected category synchronously. The UI loads
+// through RequestRefresh instead so disk reads stay off the update loop.
 func (fm *FileManager) FetchFiles(dm *DirectoryManager, tm *TaskManager) []FileInfo {
-	var files []FileInfo
-	companyFolderPath := dm.CurrentFolderPath()
-	categoryPath := strings.ToLower(dm.SelectedCategory)
-	log.Info("Fetching files for category: " + categoryPath)
-
-	path := notesPath() + "/" + companyFolderPath + "/" + categoryPath
-	log.Info("Path: " + path)
+	fm.ApplyLoadedFiles(loadFiles(fm.startLoad(dm)), dm, tm)
+	return fm.Files
+}
 
-	sorting := "default"
+// filterFiles applies the folder-scoped file filter when it targets the
+// selected company and category. A selected saved view keeps only the task
+// files with matching tasks, in the view's sort order.
+func (fm *FileManager) filterFiles(files []FileInfo, dm *DirectoryManager, tm *TaskManager) []FileInfo {
+	if view, ok := dm.CurrentSavedView(); ok && dm.SelectedCategory == "tasks" {
+		tasksByFile := tm.TaskCollection.GetTasksByFile()
+		matching := []FileInfo{}
+
+		for _, file := range files {
+			if len(tasksByFile[file.Name]) > 0 {
+				matching = append(matching, file)
+			}
+		}
 
-	if categoryPath == "tasks" {
-		sorting = "active"
+		return sortSavedViewFiles(matching, view.SortBy, tm)
 	}
 
-	files = readFilesInDirecory(path, sorting, tm)
+	filter := fm.FileFilter
+	if filter.Query == "" || filter.Company != dm.CurrentCompanyName() || filter.Category != dm.SelectedCategory {
+		return files
+	}
 
-	filenames := []string{}
-	for _, file := range files {
-		filenames = append(filenames, file.Name)
+	query, err := ParseFilterQuery(filter.Query)
+	if err != nil {
+		query.expr = textTerm{text: filter.Query}
 	}
 
-	if categoryPath == "standups" {
-		lastStandup := files[0] // The first one is the most recent
-		todayInFormat := time.Now().Format("2006-01-02")
-		todayInFormat += fm.FileExtension
+	today := time.Now().Format("2006-01-02")
+	filtered := []FileInfo{}
 
-		if lastStandup.Name != todayInFormat && isWorkingDay() {
-			if err := fm.CreateStandup(companyFolderPath); err != nil {
-				log.Warn("Failed to create standup", "error", err)
-			} else {
-				files = readFilesInDirecory(path, sorting, tm)
-			}
+	for _, file := range files {
+		if matchesFileFilter(file, query, &tm.TaskCollection, today) {
+			filtered = append(filtered, file)
 		}
 	}
 
-	log.Info("Files count: " + fmt.Sprintf("%d", len(files)))
-	fm.FetchTasks(dm, tm)
-	fm.Files = files
-
-	return files
+	log.Info("Filtered files count: " + fmt.Sprintf("%d", len(filtered)))
+	return filtered
 }
 
 func (fm *FileManager) FetchTasks(dm *DirectoryManager, tm *TaskManager) []Task {
@@ -88,10 +106,11 @@ func (fm *FileManager) FetchTasks(dm *DirectoryManager, tm *TaskManager) []Task
 	path := notesPath() + "/" + companyFolderPath + "/tasks"
 	log.Info("Path: " + path)
 
-	files := readFilesInDirecory(path, "updatedAt", tm)
+	files := readFileMetadataInDirectory(path, fm.Index, tm.FileExtension)
 	for _, file := range files {
-		tasks := tm.ExtractTasks(dm.SelectedCompany.DisplayName, file.Name, file.Content)
-		tm.TaskCollection.Add(file.Name, tasks)
+		tasks := tm.CreateTasks(dm.SelectedCompany, file.File.Name, file.Tasks)
+		tm.TaskCollection.Add(file.File.Name, tasks)
+		tm.TaskCollection.AddFileTags(file.File.Name, file.File.Tags)
 	}
 
 	tasks = tm.TaskCollection.GetAll()
@@ -106,8 +125,9 @@ func (fm FileManager) CurrentFile() FileInfo {
 	return fm.SelectedFile
 }
 
-func (fm FileManager) CurrentFileContent() string {
-	return fm.CurrentFile().Content
+func (fm *FileManager) CurrentFileContent() string {
+	content, _ := fm.FileContent(fm.CurrentFile())
+	return content
 }
 
 func (fm FileManager) CreateStandup(company string) error {
@@ -123,32 +143,36 @@ func (fm FileManager) CreateStandup(company string) error {
 	return nil
 }
 
-func (fm FileManager) CreateTask(company string, taskName string) error {
-	filePath := notesPath() + "/" + company + "/tasks/" + taskName + fm.FileExtension
-	templatePath := notesPath() + "/obsidian/templates/" + company + "_task.md"
+func (fm FileManager) CreateTask(company Company, taskName string) error {
+	filePath := notesPath() + "/" + company.FolderPathName + "/tasks/" + taskName + fm.FileExtension
+	templatePath := notesPath() + "/obsidian/templates/" + company.DisplayName + "_task.md"
 
 	err := copyFile(templatePath, filePath)
 	if err != nil {
 		return fmt.Errorf("failed to create task: %w", err)
 	}
 
+	appendHistory(HistoryEntry{Company: company.FolderPathName, File: taskName + fm.FileExtension, Task: taskName, To: "created"})
+
 	log.Info("Calling MindMapUpdater.AppendTask", "taskName", taskName)
 	fm.Updater.AppendTask(taskName, taskName)
 	return nil
 }
 
-func (fm FileManager) CreateSubTask(company string, file FileInfo, taskName string) error {
-	filePath := filepath.Join(notesPath(), "/", company, "/tasks/", file.Name)
+func (fm FileManager) CreateSubTask(company Company, file FileInfo, taskName string) error {
+	filePath := filepath.Join(notesPath(), "/", company.FolderPathName, "/tasks/", file.Name)
 
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to read file for subtask: %w", err)
 	}
 
+	inserted := false
 	lines := strings.Split(string(content), "\n")
 	for i, line := range lines {
 		if strings.Contains(line, "### Sub-tasks") {
 			lines = append(lines[:i+2], append([]string{"- [ ] " + taskName}, lines[i+2:]...)...)
+			inserted = true
 			break
 		}
 	}
@@ -159,6 +183,10 @@ func (fm FileManager) CreateSubTask(company string, file FileInfo, taskName stri
 		return fmt.Errorf("failed to write subtask: %w", err)
 	}
 
+	if inserted {
+		appendHistory(HistoryEntry{Company: company.FolderPathName, File: file.Name, Task: taskName, To: "created"})
+	}
+
 	parentTaskID := strings.TrimSuffix(file.Name, fm.FileExtension)
 	log.Info("Calling MindMapUpdater.AppendSubtask", "parentTaskID", parentTaskID, "taskName", taskName)
 	fm.Updater.AppendSubtask(parentTaskID, taskName, taskName)
@@ -166,7 +194,9 @@ func (fm FileManager) CreateSubTask(company string, file FileInfo, taskName stri
 }
 
 func (fm *FileManager) ResetCache() {
-	fm.FileCache = make(map[string][]FileInfo)
+	fm.FileCache.Clear()
+	fm.TaskCache.Clear()
+	fm.ContentCache.Clear()
 }
 
 func (fm *FileManager) UpdateTask(task Task, status string) error {
@@ -179,75 +209,28 @@ func (fm *FileManager) UpdateTask(task Task, status string) error {
 		return fmt.Errorf("failed to read task file: %w", err)
 	}
 
+	matched := false
 	lines := strings.Split(string(file), "\n")
 	for i, line := range lines {
 		if strings.Contains(line, text) {
-			if status == "scheduled" {
-				line := lines[i]
-				regex := regexp.MustCompile(`🛫\s+\d{4}-\d{2}-\d{2}`)
-				lines[i] = regex.ReplaceAllString(line, "")
-				lines[i] = strings.ReplaceAll(lines[i], "- [x]", "- [ ]")
-
-				if !strings.Contains(line, ScheduledIcon) {
-					lines[i] = lines[i] + " " + ScheduledIcon + " " + time.Now().Format("2006-01-02")
-				}
-
-				break
-			} else if status == "completed" {
-				line = strings.ReplaceAll(line, "- [ ]", "- [x]")
-				lines[i] = line + " " + CompletedIcon + " " + time.Now().Format("2006-01-02")
-
-				break
-			} else if status == "started" {
-				line := lines[i]
-				regex := regexp.MustCompile(`✅\s+\d{4}-\d{2}-\d{2}`)
-				lines[i] = regex.ReplaceAllString(line, "")
-
-				if !strings.Contains(line, StartedIcon) {
-					lines[i] = lines[i] + " " + StartedIcon + " " + time.Now().Format("2006-01-02")
-				}
-
-				break
-			} else if status == "unscheduled" {
-				line := lines[i]
-				regex := regexp.MustCompile(`⏳\s+\d{4}-\d{2}-\d{2}`)
-				lines[i] = regex.ReplaceAllString(line, "")
-
-				break
-			}
-
-			if status == "priority" {
-				line := lines[i]
-				regex := regexp.MustCompile(`\s+\d{4}-\d{2}-\d{2}`)
-				lines[i] = regex.ReplaceAllString(line, "")
-
-				if !strings.Contains(line, PriorityIcon) {
-					checkboxRegex := regexp.MustCompile(`- \[[ x]\]`)
-					if loc := checkboxRegex.FindStringIndex(line); loc != nil {
-						prefix := line[:loc[1]]
-						suffix := line[loc[1]:]
-						lines[i] = prefix + PriorityIcon + suffix
-					}
-				}
-
-				break
-			} else if status == "unpriority" {
-				line := lines[i]
-				if strings.Contains(line, PriorityIcon) {
-					lines[i] = strings.ReplaceAll(lines[i], PriorityIcon, "")
-				}
-
-				break
-			}
+			matched = true
+			lines[i] = updateTaskLine(line, task, status, time.Now().Format("2006-01-02"))
+			break
 		}
 	}
 
+	if !matched {
+		return fmt.Errorf("task %q not found in %s", strings.TrimSpace(text), filename)
+	}
+
 	newContent := strings.Join(lines, "\n")
 	err = os.WriteFile(filePath, []byte(newContent), 0644)
 	if err != nil {
 		return fmt.Errorf("failed to write updated task: %w", err)
 	}
 
+	appendHistory(taskChange(task, status))
+
 	if status == "scheduled" || status == "started" || status == "completed" || status == "unscheduled" {
 		// Clean the task text before sending to mind map updater
 		cleanText := task.textWithoutDates()
@@ -268,6 +251,100 @@ func (fm *FileManager) UpdateTask(task Task, status string) error {
 	return nil
 }
 
+// updateTaskLine applies a status change to the line of a task.
+func updateTaskLine(line string, task Task, status string, today string) string {
+	switch status {
+	case "scheduled":
+		regex := regexp.MustCompile(`🛫\s+\d{4}-\d{2}-\d{2}`)
+		updated := strings.ReplaceAll(regex.ReplaceAllString(line, ""), "- [x]", "- [ ]")
+
+		if !strings.Contains(line, ScheduledIcon) {
+			return updated + " " + ScheduledIcon + " " + today
+		}
+		return updated
+	case "completed":
+		return strings.ReplaceAll(line, "- [ ]", "- [x]") + " " + CompletedIcon + " " + today
+	case "started":
+		regex := regexp.MustCompile(`✅\s+\d{4}-\d{2}-\d{2}`)
+		updated := regex.ReplaceAllString(line, "")
+
+		if !strings.Contains(line, StartedIcon) {
+			return updated + " " + StartedIcon + " " + today
+		}
+		return updated
+	case "unscheduled":
+		regex := regexp.MustCompile(`⏳\s+\d{4}-\d{2}-\d{2}`)
+		return regex.ReplaceAllString(line, "")
+	case "rescheduled":
+		// Moves the task to its ScheduledDate
+		regex := regexp.MustCompile(`⏳\s+\d{4}-\d{2}-\d{2}`)
+		if regex.MatchString(line) {
+			return regex.ReplaceAllString(line, ScheduledIcon+" "+task.ScheduledDate)
+		}
+		return line + " " + ScheduledIcon + " " + task.ScheduledDate
+	case "priority":
+		regex := regexp.MustCompile(`\s+\d{4}-\d{2}-\d{2}`)
+		updated := regex.ReplaceAllString(line, "")
+
+		if !strings.Contains(line, PriorityIcon) {
+			checkboxRegex := regexp.MustCompile(`- \[[ x]\]`)
+			if loc := checkboxRegex.FindStringIndex(line); loc != nil {
+				return line[:loc[1]] + PriorityIcon + line[loc[1]:]
+			}
+		}
+
+		return updated
+	case "unpriority":
+		return strings.ReplaceAll(line, PriorityIcon, "")
+	}
+
+	return line
+}
+
+// editTaskLine rewrites the line of the task with edit.
+func (fm *FileManager) editTaskLine(task Task, edit func(line string) string) error {
+	filePath := notesPath() + "/" + task.Company + "/tasks/" + task.FileName
+	file, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read task file: %w", err)
+	}
+
+	lines := strings.Split(string(file), "\n")
+	for i, line := range lines {
+		if strings.Contains(line, task.Text) {
+			lines[i] = edit(line)
+			if lines[i] == line {
+				return nil
+			}
+
+			if err := os.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+				return fmt.Errorf("failed to write updated task: %w", err)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("task %q not found in %s", strings.TrimSpace(task.Text), task.FileName)
+}
+
+// taskWithLine returns the task as it reads once edit rewrites its line.
+func taskWithLine(task Task, edit func(line string) string) Task {
+	checkbox := "- [ ]"
+	if task.IsDone {
+		checkbox = "- [x]"
+	}
+
+	fileTasks := utils.ExtractTasksFromText(edit(checkbox + task.Text))
+	if len(fileTasks) == 0 {
+		return task
+	}
+
+	updated := createTaskFromFileTask(task.Company, task.FileName, fileTasks[0])
+	updated.CompanyName = task.CompanyName
+	updated.LineNumber = task.LineNumber
+	return updated
+}
+
 // If it does exist, it will be overwritten.
 func copyFile(src, dst string) error {
 	sourceFile, err := os.Open(src)
@@ -301,10 +378,14 @@ func (fm *FileManager) SelectFile(filename string) {
 func (fm *FileManager) PeopleFilenames(dm *DirectoryManager, tm *TaskManager, filterValue string) []string {
 	path := notesPath() + "/" + dm.CurrentFolderPath() + "/people"
 
-	files := readFilesInDirecory(path, "default", tm)
+	files := readFileMetadataInDirectory(path, fm.Index, tm.FileExtension)
+	slices.SortFunc(files, func(a, b IndexedFile) int {
+		return nameCmp(a.File, b.File)
+	})
 
 	filenames := []string{}
-	for _, file := range files {
+	for _, indexed := range files {
+		file := indexed.File
 		if filterValue == "" {
 			filenames = append(filenames, file.Name)
 		} else if strings.Contains(strings.ToLower(file.Name), strings.ToLower(filterValue)) {
@@ -319,10 +400,14 @@ func (fm *FileManager) PeopleFilenames(dm *DirectoryManager, tm *TaskManager, fi
 func (fm *FileManager) TaskFilenames(dm *DirectoryManager, tm *TaskManager, filterValue string) []string {
 	path := notesPath() + "/" + dm.CurrentFolderPath() + "/tasks"
 
-	files := readFilesInDirecory(path, "default", tm)
+	files := readFileMetadataInDirectory(path, fm.Index, tm.FileExtension)
+	slices.SortFunc(files, func(a, b IndexedFile) int {
+		return nameCmp(a.File, b.File)
+	})
 
 	filenames := []string{}
-	for _, file := range files {
+	for _, indexed := range files {
+		file := indexed.File
 		if filterValue == "" {
 			filenames = append(filenames, file.Name)
 		} else if strings.Contains(strings.ToLower(file.Name), strings.ToLower(filterValue)) {
@@ -361,76 +446,6 @@ func (fm FileManager) currentFileName() string {
 	return fm.CurrentFile().Name
 }
 
-func readFilesInDirecory(path string, sortBy string, tm *TaskManager) []FileInfo {
-	var fileInfos []FileInfo
-
-	files, err := os.ReadDir(path)
-	if err != nil {
-		log.Warn("Failed to read directory", "path", path, "error", err)
-		return fileInfos // Return empty slice instead of crashing
-	}
-
-	for _, file := range files {
-		if file.IsDir() {
-			continue
-		}
-
-		if !strings.HasSuffix(file.Name(), tm.FileExtension) {
-			continue
-		}
-
-		if strings.HasPrefix(file.Name(), "sortspec") {
-			continue
-		}
-
-		fullPath := filepath.Join(path, file.Name())
-		content, err := os.ReadFile(fullPath)
-		if err != nil {
-			log.Warn("Failed to read file", "path", fullPath, "error", err)
-			continue // Skip this file instead of crashing
-		}
-
-		// Extract title from YAML frontmatter if it exists
-		contentStr := string(content)
-		title := extractTitleFromYAML(contentStr)
-
-		// Create display name with title if available
-		displayName := ""
-		if title != "" {
-			displayName = title
-		}
-
-		// Remove YAML frontmatter
-		contentStr = removeYAMLFrontmatter(contentStr)
-
-		fileInfo, err := file.Info()
-		if err != nil {
-			log.Warn("Failed to get file info", "path", fullPath, "error", err)
-			continue // Skip this file instead of crashing
-		}
-
-		newFileInfo := FileInfo{
-			Name:        file.Name(),
-			DisplayName: displayName,
-			Content:     contentStr,
-			UpdatedAt:   fileInfo.ModTime(),
-			FullPath:    fullPath,
-		}
-
-		fileInfos = append(fileInfos, newFileInfo)
-	}
-
-	if sortBy == "updatedAt" {
-		slices.SortFunc(fileInfos, updatedAtCmp)
-	} else if sortBy == "active" {
-		fileInfos = sortedFiles(fileInfos, tm)
-	} else {
-		slices.SortFunc(fileInfos, nameCmp)
-	}
-
-	return fileInfos
-}
-
 // extractTitleFromYAML extracts the title field from YAML frontmatter if it exists
 func extractTitleFromYAML(content string) string {
 	if !strings.HasPrefix(strings.TrimSpace(content), "---") {
@@ -465,6 +480,61 @@ func extractTitleFromYAML(content string) string {
 	return ""
 }
 
+// extractTagsFromYAML extracts the tags field from YAML frontmatter if it exists.
+// Both the inline (tags: [a, b]) and the list (tags:\n  - a) forms are supported.
+func extractTagsFromYAML(content string) []string {
+	if !strings.HasPrefix(strings.TrimSpace(content), "---") {
+		return nil
+	}
+
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	var tags []string
+	inTagsList := false
+
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "---" {
+			break
+		}
+
+		if inTagsList {
+			if strings.HasPrefix(line, "- ") {
+				tags = appendYAMLTag(tags, strings.TrimPrefix(line, "- "))
+				continue
+			}
+			inTagsList = false
+		}
+
+		if !strings.HasPrefix(line, "tags:") {
+			continue
+		}
+
+		value := strings.TrimSpace(strings.TrimPrefix(line, "tags:"))
+		if value == "" {
+			inTagsList = true
+			continue
+		}
+
+		value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+		for _, tag := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+			tags = appendYAMLTag(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+func appendYAMLTag(tags []string, tag string) []string {
+	tag = strings.Trim(strings.TrimSpace(tag), `"'`)
+	tag = strings.TrimPrefix(tag, "#")
+
+	if tag == "" || containsFold(tags, tag) {
+		return tags
+	}
+
+	return append(tags, tag)
+}
+
 // removeYAMLFrontmatter removes YAML frontmatter from content if it exists
 func removeYAMLFrontmatter(content string) string {
 	// Check if content starts with "---" which indicates YAML frontmatter
@@ -603,3 +673,13 @@ func notesPath() string {
 	homeDir, _ := os.UserHomeDir()
 	return homeDir + "/Notes"
 }
+
+// expandHome replaces a leading ~/ of a configured path with the home
+// directory.
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		homeDir, _ := os.UserHomeDir()
+		return filepath.Join(homeDir, rest)
+	}
+	return path
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractTagsFromYAML(t *testing.T) {
+	cases := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{name: "no frontmatter", content: "# Title\n#bug", expected: nil},
+		{name: "inline list", content: "---\ntitle: Login\ntags: [bug, \"#review\"]\n---\nBody", expected: []string{"bug", "review"}},
+		{name: "single value", content: "---\ntags: waiting\n---\n", expected: []string{"waiting"}},
+		{name: "block list", content: "---\ntags:\n  - bug\n  - client/clerky\ntitle: Login\n---\n", expected: []string{"bug", "client/clerky"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual := extractTagsFromYAML(c.content)
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"fmt"
 	"net/url"
 	"os"
 	"os/exec"
@@ -32,17 +33,7 @@ func (fo FileOperations) HandleKey(key string, m *Model) tea.Cmd {
 func (fo FileOperations) OpenInVim(m *Model) tea.Cmd {
 	if m.IsDetailsView() || m.IsKanbanView() {
 		log.Info("Opening file in vim", m.FileManager.SelectedFile.Name)
-		filePath := m.FileManager.SelectedFile.FullPath
-
-		c := exec.Command("vim", "-u", "~/.dotfiles/.vimrc", filePath)
-
-		// Use tea.ExecProcess for non-blocking execution
-		return tea.ExecProcess(c, func(err error) tea.Msg {
-			if err != nil {
-				return EditorClosedMsg{Err: err}
-			}
-			return EditorClosedMsg{}
-		})
+		return openInVim(m.FileManager.SelectedFile.FullPath, 0)
 	}
 	return nil
 }
@@ -50,28 +41,51 @@ func (fo FileOperations) OpenInVim(m *Model) tea.Cmd {
 // OpenInObsidian opens the current file in Obsidian using non-blocking tea.ExecProcess
 func (fo FileOperations) OpenInObsidian(m *Model) tea.Cmd {
 	if m.IsDetailsView() || m.IsKanbanView() {
-		filePath := m.FileManager.SelectedFile.FullPath
-		homeDir, _ := os.UserHomeDir()
-		notesPath := homeDir + "/Notes"
-		obsidianPath := constructObsidianURL(filePath, notesPath)
-
-		c := exec.Command("open", "-a", "Obsidian", obsidianPath)
-
-		// Use tea.ExecProcess for non-blocking execution
-		return tea.ExecProcess(c, func(err error) tea.Msg {
-			if err != nil {
-				return ErrorOccurredMsg{
-					Err:     err,
-					Context: "opening in Obsidian",
-				}
-			}
-			// Obsidian opened successfully, no need to reload file
-			return nil
-		})
+		return openInObsidian(m.FileManager.SelectedFile.FullPath)
 	}
 	return nil
 }
 
+// openInVim opens a file in Vim, at the given 1-based line when it is set.
+func openInVim(filePath string, line int) tea.Cmd {
+	args := []string{"-u", "~/.dotfiles/.vimrc"}
+	if line > 0 {
+		args = append(args, fmt.Sprintf("+%d", line))
+	}
+
+	c := exec.Command("vim", append(args, filePath)...)
+
+	// Use tea.ExecProcess for non-blocking execution
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		if err != nil {
+			return EditorClosedMsg{Err: err}
+		}
+		return EditorClosedMsg{}
+	})
+}
+
+// openInObsidian opens a vault file in Obsidian. Obsidian's open URI has no
+// line parameter, so the note opens at its top.
+func openInObsidian(filePath string) tea.Cmd {
+	homeDir, _ := os.UserHomeDir()
+	notesPath := homeDir + "/Notes"
+	obsidianPath := constructObsidianURL(filePath, notesPath)
+
+	c := exec.Command("open", "-a", "Obsidian", obsidianPath)
+
+	// Use tea.ExecProcess for non-blocking execution
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		if err != nil {
+			return ErrorOccurredMsg{
+				Err:     err,
+				Context: "opening in Obsidian",
+			}
+		}
+		// Obsidian opened successfully, no need to reload file
+		return nil
+	})
+}
+
 // NextCompany switches to the next company
 func (fo FileOperations) NextCompany(m *Model) tea.Cmd {
 	m.GoToNextCompany()
@@ -0,0 +1,544 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// filter_query.go implements the small query language used by the / filter:
+//
+//	status:started company:clerky tag:bug due:<7d file:onboarding "free text"
+//
+// Terms are combined with AND (implicit when terms are next to each other),
+// OR and NOT, and can be grouped with parentheses. #bug is a shorthand for
+// tag:bug and a leading - negates a term.
+
+// FilterQuery is a parsed filter value.
+type FilterQuery struct {
+	source string
+	expr   filterExpr
+	err    error
+}
+
+// filterTarget is what a filter expression is evaluated against: a task with
+// the file it belongs to. Notes without tasks use an empty task.
+type filterTarget struct {
+	task     Task
+	filename string
+	fileTags []string
+	content  string
+	date     string
+}
+
+type filterExpr interface {
+	matches(target filterTarget) bool
+}
+
+type (
+	andExpr struct {
+		left, right filterExpr
+	}
+
+	orExpr struct {
+		left, right filterExpr
+	}
+
+	notExpr struct {
+		expr filterExpr
+	}
+
+	matchAllExpr struct{}
+
+	textTerm struct {
+		text string
+	}
+
+	statusTerm struct {
+		status string
+	}
+
+	companyTerm struct {
+		company string
+	}
+
+	tagTerm struct {
+		tag string
+	}
+
+	fileTerm struct {
+		file string
+	}
+
+	dateTerm struct {
+		field    string
+		operator string
+		date     string
+		relative func(today string) string
+	}
+)
+
+var filterStatuses = []string{"unscheduled", "scheduled", "started", "completed", "done", "overdue", "priority", "open", "active"}
+
+var relativeDateRegex = regexp.MustCompile(`^(-?\d+)([dw])$`)
+
+// ParseFilterQuery parses a filter value. An empty value matches everything.
+func ParseFilterQuery(value string) (*FilterQuery, error) {
+	query := &FilterQuery{source: value}
+
+	tokens, err := tokenizeFilter(value)
+	if err != nil {
+		query.err = err
+		return query, err
+	}
+
+	if len(tokens) == 0 {
+		query.expr = matchAllExpr{}
+		return query, nil
+	}
+
+	parser := filterParser{tokens: tokens}
+	expr, err := parser.parseOr()
+	if err == nil && parser.position < len(parser.tokens) {
+		err = fmt.Errorf("unexpected %q", parser.tokens[parser.position].text)
+	}
+
+	if err != nil {
+		query.err = err
+		return query, err
+	}
+
+	query.expr = expr
+	return query, nil
+}
+
+// Matches reports whether the task in the given file matches the query.
+func (q *FilterQuery) Matches(task Task, filename string, fileTags []string, date string) bool {
+	return q.expr.matches(filterTarget{task: task, filename: filename, fileTags: fileTags, date: date})
+}
+
+// MatchesNote reports whether a note with the given content matches the query.
+// Terms about task state never match a note on its own.
+func (q *FilterQuery) MatchesNote(filename string, fileTags []string, content string, date string) bool {
+	return q.expr.matches(filterTarget{filename: filename, fileTags: fileTags, content: content, date: date})
+}
+
+// Tags returns the tags the query refers to, whether through tag: or #tag.
+func (q *FilterQuery) Tags() []string {
+	var tags []string
+	collectFilterTags(q.expr, &tags)
+	return tags
+}
+
+func collectFilterTags(expr filterExpr, tags *[]string) {
+	switch e := expr.(type) {
+	case andExpr:
+		collectFilterTags(e.left, tags)
+		collectFilterTags(e.right, tags)
+	case orExpr:
+		collectFilterTags(e.left, tags)
+		collectFilterTags(e.right, tags)
+	case tagTerm:
+		*tags = append(*tags, e.tag)
+	}
+}
+
+func (e andExpr) matches(t filterTarget) bool {
+	return e.left.matches(t) && e.right.matches(t)
+}
+
+func (e orExpr) matches(t filterTarget) bool {
+	return e.left.matches(t) || e.right.matches(t)
+}
+
+func (e notExpr) matches(t filterTarget) bool {
+	return !e.expr.matches(t)
+}
+
+func (e matchAllExpr) matches(t filterTarget) bool {
+	return true
+}
+
+func (e textTerm) matches(t filterTarget) bool {
+	return includesLowercase(t.task.Text, e.text) || includesLowercase(t.filename, e.text) || includesLowercase(t.content, e.text)
+}
+
+func (e statusTerm) matches(t filterTarget) bool {
+	if t.task.Text == "" {
+		return false
+	}
+
+	task := t.task
+
+	switch e.status {
+	case "unscheduled":
+		return !task.Scheduled && !task.Started && !task.Completed
+	case "scheduled":
+		return task.IsScheduled()
+	case "started":
+		return task.IsStarted()
+	case "completed", "done":
+		return task.Completed
+	case "overdue":
+		return !task.Completed && task.StatusAtDate(t.date) == overdue
+	case "priority":
+		return task.Priority != "" && !task.Completed
+	case "open":
+		return !task.Completed
+	case "active":
+		return !task.IsInactive()
+	}
+
+	return false
+}
+
+func (e companyTerm) matches(t filterTarget) bool {
+	return includesLowercase(t.task.CompanyName, e.company) || includesLowercase(t.task.Company, e.company)
+}
+
+func (e tagTerm) matches(t filterTarget) bool {
+	return t.task.HasTag(e.tag) || containsFold(t.fileTags, e.tag)
+}
+
+func (e fileTerm) matches(t filterTarget) bool {
+	return includesLowercase(t.filename, e.file)
+}
+
+func (e dateTerm) matches(t filterTarget) bool {
+	value := taskDateForField(t.task, e.field)
+
+	if e.operator == "none" {
+		return t.task.Text != "" && value == ""
+	}
+
+	if value == "" {
+		return false
+	}
+
+	date := e.date
+	if e.relative != nil {
+		date = e.relative(t.date)
+	}
+
+	switch e.operator {
+	case "<":
+		return value < date
+	case "<=":
+		return value <= date
+	case ">":
+		return value > date
+	case ">=":
+		return value >= date
+	}
+
+	return value == date
+}
+
+// taskDateForField returns the date a date term compares against. due: uses
+// the 📅 due date and falls back to the ⏳ scheduled date.
+func taskDateForField(task Task, field string) string {
+	switch field {
+	case "due":
+		if task.DueDate != "" {
+			return task.DueDate
+		}
+		return task.ScheduledDate
+	case "scheduled":
+		return task.ScheduledDate
+	case "started":
+		return task.StartDate
+	case "completed":
+		return task.CompletedDate
+	}
+
+	return ""
+}
+
+type filterTokenKind int
+
+const (
+	wordToken filterTokenKind = iota
+	leftParenToken
+	rightParenToken
+)
+
+type filterToken struct {
+	kind   filterTokenKind
+	text   string
+	quoted bool
+}
+
+func tokenizeFilter(value string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(value)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, filterToken{kind: leftParenToken, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{kind: rightParenToken, text: ")"})
+			i++
+		default:
+			var word strings.Builder
+			quoted := false
+
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+				if runes[i] != '"' {
+					word.WriteRune(runes[i])
+					i++
+					continue
+				}
+
+				closing := strings.IndexRune(string(runes[i+1:]), '"')
+				if closing == -1 {
+					return nil, fmt.Errorf("unterminated quote")
+				}
+
+				quotedText := []rune(string(runes[i+1:])[:closing])
+				word.WriteString(string(quotedText))
+				i += len(quotedText) + 2
+				quoted = true
+			}
+
+			tokens = append(tokens, filterToken{kind: wordToken, text: word.String(), quoted: quoted})
+		}
+	}
+
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens   []filterToken
+	position int
+}
+
+func (p *filterParser) peek() (filterToken, bool) {
+	if p.position >= len(p.tokens) {
+		return filterToken{}, false
+	}
+
+	return p.tokens[p.position], true
+}
+
+func (p *filterParser) isKeyword(keyword string) bool {
+	token, ok := p.peek()
+	return ok && token.kind == wordToken && !token.quoted && token.text == keyword
+}
+
+func (p *filterParser) parseOr() (filterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("OR") {
+		p.position++
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = orExpr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if p.isKeyword("AND") {
+			p.position++
+		} else if token, ok := p.peek(); !ok || token.kind == rightParenToken || p.isKeyword("OR") {
+			return left, nil
+		}
+
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		left = andExpr{left: left, right: right}
+	}
+}
+
+func (p *filterParser) parseNot() (filterExpr, error) {
+	if p.isKeyword("NOT") {
+		p.position++
+
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return notExpr{expr: expr}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (filterExpr, error) {
+	token, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("expected a term at the end of the filter")
+	}
+
+	switch token.kind {
+	case leftParenToken:
+		p.position++
+
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing, ok := p.peek(); !ok || closing.kind != rightParenToken {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.position++
+
+		return expr, nil
+	case rightParenToken:
+		return nil, fmt.Errorf("unexpected %q", token.text)
+	}
+
+	p.position++
+
+	if token.text == "AND" || token.text == "OR" {
+		if !token.quoted {
+			return nil, fmt.Errorf("%s needs a term on both sides", token.text)
+		}
+	}
+
+	return parseFilterTerm(token)
+}
+
+func parseFilterTerm(token filterToken) (filterExpr, error) {
+	text := token.text
+
+	if !token.quoted && len(text) > 1 && strings.HasPrefix(text, "-") {
+		term, err := parseFilterTerm(filterToken{kind: wordToken, text: text[1:]})
+		if err != nil {
+			return nil, err
+		}
+
+		return notExpr{expr: term}, nil
+	}
+
+	if !token.quoted && len(text) > 1 && strings.HasPrefix(text, "#") {
+		return tagTerm{tag: text[1:]}, nil
+	}
+
+	field, value, hasField := strings.Cut(text, ":")
+	if !hasField || strings.ContainsAny(field, " ") {
+		return textTerm{text: text}, nil
+	}
+
+	field = strings.ToLower(field)
+
+	switch field {
+	case "status", "company", "tag", "file", "text", "due", "scheduled", "started", "completed":
+	default:
+		// Things like "re: onboarding" or URLs are free text, not fields
+		return textTerm{text: text}, nil
+	}
+
+	if value == "" {
+		return nil, fmt.Errorf("missing value for %s:", field)
+	}
+
+	switch field {
+	case "status":
+		status := strings.ToLower(value)
+		for _, known := range filterStatuses {
+			if status == known {
+				return statusTerm{status: status}, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown status %q (expected one of %s)", value, strings.Join(filterStatuses, ", "))
+	case "company":
+		return companyTerm{company: value}, nil
+	case "tag":
+		return tagTerm{tag: strings.TrimPrefix(value, "#")}, nil
+	case "file":
+		return fileTerm{file: value}, nil
+	case "text":
+		return textTerm{text: value}, nil
+	}
+
+	return parseDateTerm(field, value)
+}
+
+// parseDateTerm parses values such as <7d, >=2024-05-01, today, overdue or none.
+func parseDateTerm(field string, value string) (filterExpr, error) {
+	lowerValue := strings.ToLower(value)
+
+	switch lowerValue {
+	case "none":
+		return dateTerm{field: field, operator: "none"}, nil
+	case "overdue":
+		return andExpr{
+			left:  statusTerm{status: "open"},
+			right: dateTerm{field: field, operator: "<", relative: relativeDate(0)},
+		}, nil
+	}
+
+	operator := "="
+	for _, candidate := range []string{"<=", ">=", "<", ">", "="} {
+		if strings.HasPrefix(lowerValue, candidate) {
+			operator = candidate
+			lowerValue = strings.TrimPrefix(lowerValue, candidate)
+			break
+		}
+	}
+
+	term := dateTerm{field: field, operator: operator}
+
+	switch {
+	case lowerValue == "today":
+		term.relative = relativeDate(0)
+	case lowerValue == "tomorrow":
+		term.relative = relativeDate(1)
+	case lowerValue == "yesterday":
+		term.relative = relativeDate(-1)
+	case relativeDateRegex.MatchString(lowerValue):
+		match := relativeDateRegex.FindStringSubmatch(lowerValue)
+		amount, _ := strconv.Atoi(match[1])
+		if match[2] == "w" {
+			amount *= 7
+		}
+		term.relative = relativeDate(amount)
+	default:
+		if _, err := time.Parse("2006-01-02", lowerValue); err != nil {
+			return nil, fmt.Errorf("invalid date %q for %s: (use YYYY-MM-DD, today, 7d or 2w)", value, field)
+		}
+		term.date = lowerValue
+	}
+
+	return term, nil
+}
+
+func relativeDate(days int) func(today string) string {
+	return func(today string) string {
+		date, err := time.Parse("2006-01-02", today)
+		if err != nil {
+			date = time.Now()
+		}
+
+		return date.AddDate(0, 0, days).Format("2006-01-02")
+	}
+}
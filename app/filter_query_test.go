@@ -0,0 +1,99 @@
+package app
+
+import (
+	"testing"
+)
+
+func TestFilterQueryMatches(t *testing.T) {
+	today := "2024-03-11"
+
+	tasks := map[string]Task{
+		"started bug":     {Text: "Fix login #bug 🛫 2024-03-08", Company: "clerky", CompanyName: "Clerky", Started: true, StartDate: "2024-03-08", Tags: []string{"bug"}},
+		"scheduled":       {Text: "Write docs ⏳ 2024-03-14", Company: "clerky", CompanyName: "Clerky", Scheduled: true, ScheduledDate: "2024-03-14"},
+		"due later":       {Text: "Renew domain 📅 2024-04-30", Company: "lifeplus", CompanyName: "Lifeplus", DueDate: "2024-04-30"},
+		"completed":       {Text: "Ship release ✅ 2024-03-01", Company: "lifeplus", CompanyName: "Lifeplus", Completed: true, CompletedDate: "2024-03-01"},
+		"overdue waiting": {Text: "Ask for access #waiting ⏳ 2024-02-01", Company: "qvest_us", CompanyName: "Qvest.US", Scheduled: true, ScheduledDate: "2024-02-01", Tags: []string{"waiting"}},
+	}
+
+	cases := []struct {
+		query    string
+		expected []string
+	}{
+		{query: "status:started", expected: []string{"started bug"}},
+		{query: "company:clerky tag:bug", expected: []string{"started bug"}},
+		{query: "company:clerky AND #bug", expected: []string{"started bug"}},
+		{query: "tag:bug OR tag:waiting", expected: []string{"started bug", "overdue waiting"}},
+		{query: "NOT company:clerky status:open", expected: []string{"due later", "overdue waiting"}},
+		{query: "-company:clerky -status:completed", expected: []string{"due later", "overdue waiting"}},
+		{query: "(company:lifeplus OR company:qvest) status:open", expected: []string{"due later", "overdue waiting"}},
+		{query: "company:\"Qvest.US\"", expected: []string{"overdue waiting"}},
+		{query: "company:qvest_us", expected: []string{"overdue waiting"}},
+		{query: "due:<7d", expected: []string{"scheduled", "overdue waiting"}},
+		{query: "due:overdue", expected: []string{"overdue waiting"}},
+		{query: "due:>=2024-04-01", expected: []string{"due later"}},
+		{query: "due:none", expected: []string{"started bug", "completed"}},
+		{query: "file:onboarding", expected: []string{"started bug", "scheduled", "due later", "completed", "overdue waiting"}},
+		{query: "\"renew domain\"", expected: []string{"due later"}},
+		{query: "status:overdue", expected: []string{"overdue waiting"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.query, func(t *testing.T) {
+			query, err := ParseFilterQuery(c.query)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %s", err)
+			}
+
+			for name, task := range tasks {
+				expected := false
+				for _, expectedName := range c.expected {
+					if expectedName == name {
+						expected = true
+					}
+				}
+
+				if actual := query.Matches(task, "onboarding.md", nil, today); actual != expected {
+					t.Errorf("%q: expected match %v, got %v", name, expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFilterQueryErrors(t *testing.T) {
+	cases := []string{
+		"(status:started",
+		"status:started)",
+		"status:someday",
+		"due:soon",
+		"tag:",
+		"\"unterminated",
+		"company:clerky OR",
+		"NOT",
+	}
+
+	for _, value := range cases {
+		t.Run(value, func(t *testing.T) {
+			if _, err := ParseFilterQuery(value); err == nil {
+				t.Errorf("expected %q to fail to parse", value)
+			}
+		})
+	}
+}
+
+func TestTaskCollectionFilterFallsBackToTextOnParseError(t *testing.T) {
+	tc := TaskCollection{
+		TasksByFile: map[string][]Task{
+			"notes.md": {{Text: "Read (chapter 3"}},
+		},
+		FilterValue: "(chapter",
+	}
+
+	if tc.FilterError() == nil {
+		t.Fatal("expected a parse error")
+	}
+
+	if len(tc.GetTasksByFile()["notes.md"]) != 1 {
+		t.Errorf("expected the invalid filter to fall back to a substring match")
+	}
+}
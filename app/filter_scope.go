@@ -0,0 +1,101 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FileFilter limits the files listed for a company's category to the notes
+// matching a query. It is set by submitting a folder-scoped filter such as
+// /clerky/meetings/roadmap.
+type FileFilter struct {
+	Company  string
+	Category string
+	Query    string
+}
+
+// filterScope is the folder part of a /{{company}}/{{folder}}/{{filter}} value.
+type filterScope struct {
+	Company  Company
+	Category string
+}
+
+// parseFilterScope splits a folder-scoped filter value into its scope and its
+// query. The company segment is optional; /meetings/roadmap searches the
+// meetings folder of the current company. ok is false for plain filter values.
+func parseFilterScope(value string, dm *DirectoryManager) (filterScope, string, bool, error) {
+	if !strings.HasPrefix(value, "/") {
+		return filterScope{}, value, false, nil
+	}
+
+	segments := strings.Split(strings.TrimPrefix(value, "/"), "/")
+	first := strings.ToLower(strings.TrimSpace(segments[0]))
+
+	if first == "" {
+		return filterScope{}, "", true, fmt.Errorf("expected /{{folder}}/{{filter}} or /{{company}}/{{folder}}/{{filter}}")
+	}
+
+	for _, company := range dm.Companies {
+		if strings.ToLower(company.DisplayName) != first && strings.ToLower(company.FolderPathName) != first {
+			continue
+		}
+
+		if len(segments) < 2 || strings.TrimSpace(segments[1]) == "" {
+			return filterScope{Company: company}, "", true, fmt.Errorf("expected a folder after /%s/", segments[0])
+		}
+
+		category, found := companyCategory(company, segments[1])
+		if !found {
+			return filterScope{Company: company}, "", true, fmt.Errorf("%s has no %q folder", company.DisplayName, segments[1])
+		}
+
+		return filterScope{Company: company, Category: category}, strings.Join(segments[2:], "/"), true, nil
+	}
+
+	category, found := companyCategory(dm.SelectedCompany, segments[0])
+	if !found {
+		return filterScope{}, "", true, fmt.Errorf("no company or folder named %q", segments[0])
+	}
+
+	return filterScope{Company: dm.SelectedCompany, Category: category}, strings.Join(segments[1:], "/"), true, nil
+}
+
+func companyCategory(company Company, name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	for _, subFolder := range company.SubFolders {
+		if strings.ToLower(subFolder) == name {
+			return subFolder, true
+		}
+	}
+
+	return "", false
+}
+
+// filterQueryPart returns the part of a filter value that applies to tasks.
+// Incomplete folder scopes filter nothing until they are valid.
+func filterQueryPart(value string, dm *DirectoryManager) string {
+	_, query, _, err := parseFilterScope(value, dm)
+	if err != nil {
+		return ""
+	}
+
+	return query
+}
+
+// matchesFileFilter reports whether a note belongs in a filtered file list,
+// either because its name or content matches or, for task files, because one
+// of its tasks does.
+func matchesFileFilter(file FileInfo, query *FilterQuery, tc *TaskCollection, date string) bool {
+	if query.MatchesNote(file.Name, file.Tags, file.Content, date) {
+		return true
+	}
+
+	for _, task := range tc.TasksByFile[file.Name] {
+		if query.Matches(task, file.Name, file.Tags, date) {
+			return true
+		}
+	}
+
+	return false
+}
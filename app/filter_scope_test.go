@@ -0,0 +1,93 @@
+package app
+
+import (
+	"testing"
+)
+
+func TestParseFilterScope(t *testing.T) {
+	clerky := Company{DisplayName: "Clerky", FolderPathName: "clerky", SubFolders: []string{"tasks", "meetings"}}
+	qvest := Company{DisplayName: "Qvest.US", FolderPathName: "qvest_us", SubFolders: []string{"tasks", "people"}}
+	dm := DirectoryManager{Companies: []Company{clerky, qvest}, SelectedCompany: clerky}
+
+	cases := []struct {
+		name             string
+		value            string
+		expectedCompany  string
+		expectedCategory string
+		expectedQuery    string
+		expectedScoped   bool
+		expectError      bool
+	}{
+		{name: "plain filter", value: "roadmap", expectedQuery: "roadmap"},
+		{name: "company and folder", value: "/clerky/meetings/roadmap", expectedCompany: "Clerky", expectedCategory: "meetings", expectedQuery: "roadmap", expectedScoped: true},
+		{name: "company by folder name", value: "/qvest_us/people/", expectedCompany: "Qvest.US", expectedCategory: "people", expectedScoped: true},
+		{name: "folder of current company", value: "/meetings/status:open roadmap", expectedCompany: "Clerky", expectedCategory: "meetings", expectedQuery: "status:open roadmap", expectedScoped: true},
+		{name: "query keeps slashes", value: "/clerky/tasks/file:a/b", expectedCompany: "Clerky", expectedCategory: "tasks", expectedQuery: "file:a/b", expectedScoped: true},
+		{name: "unknown folder", value: "/qvest_us/meetings/roadmap", expectedScoped: true, expectError: true},
+		{name: "unknown company", value: "/acme/tasks/x", expectedScoped: true, expectError: true},
+		{name: "missing folder", value: "/clerky", expectedScoped: true, expectError: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			scope, query, scoped, err := parseFilterScope(c.value, &dm)
+
+			if (err != nil) != c.expectError {
+				t.Fatalf("expected error %v, got %v", c.expectError, err)
+			}
+
+			if scoped != c.expectedScoped {
+				t.Errorf("expected scoped %v, got %v", c.expectedScoped, scoped)
+			}
+
+			if c.expectError {
+				return
+			}
+
+			if scope.Company.DisplayName != c.expectedCompany || scope.Category != c.expectedCategory || query != c.expectedQuery {
+				t.Errorf("expected %s/%s/%s, got %s/%s/%s", c.expectedCompany, c.expectedCategory, c.expectedQuery, scope.Company.DisplayName, scope.Category, query)
+			}
+		})
+	}
+}
+
+func TestMatchesFileFilter(t *testing.T) {
+	tc := TaskCollection{
+		TasksByFile: map[string][]Task{
+			"launch.md": {{Text: "Prepare roadmap slides", Started: true}},
+		},
+	}
+	query, _ := ParseFilterQuery("roadmap")
+
+	if !matchesFileFilter(FileInfo{Name: "2024-03-01 sync.md", Content: "We discussed the roadmap"}, query, &tc, "2024-03-11") {
+		t.Error("expected a note whose content matches to be included")
+	}
+
+	if !matchesFileFilter(FileInfo{Name: "launch.md", Content: "Launch plan"}, query, &tc, "2024-03-11") {
+		t.Error("expected a task file whose tasks match to be included")
+	}
+
+	if matchesFileFilter(FileInfo{Name: "hiring.md", Content: "Interview loop"}, query, &tc, "2024-03-11") {
+		t.Error("expected an unrelated note to be excluded")
+	}
+}
+
+func TestScopedFilterClearedOnGoingBack(t *testing.T) {
+	clerky := Company{DisplayName: "Clerky", FolderPathName: "clerky", SubFolders: []string{"tasks", "meetings"}}
+	m := &Model{
+		DirectoryManager: DirectoryManager{Companies: []Company{clerky}, SelectedCompany: clerky, Categories: []string{"tasks", "meetings"}},
+		ViewManager:      ViewManager{CurrentView: CategoriesView},
+	}
+
+	if err := m.SubmitFilter("/meetings/roadmap"); err != nil {
+		t.Fatal(err)
+	}
+	if m.FileManager.FileFilter.Category != "meetings" || !m.IsDetailsView() {
+		t.Fatalf("expected the meetings to be filtered, got %+v", m.FileManager.FileFilter)
+	}
+
+	m.GoToPreviousView()
+	if m.FileManager.FileFilter != (FileFilter{}) || m.TaskManager.TaskCollection.FilterValue != "" {
+		t.Errorf("expected the scoped filter to be cleared, got %+v and %q", m.FileManager.FileFilter, m.TaskManager.TaskCollection.FilterValue)
+	}
+}
@@ -0,0 +1,242 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"vision/config"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// flowWeeks is the number of weeks the stats view covers.
+const flowWeeks = 12
+
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// Durations summarises task durations in days.
+type Durations struct {
+	Count  int     `json:"count"`
+	Median float64 `json:"median"`
+	P90    float64 `json:"p90"`
+}
+
+// WeekMetrics are the flow metrics of the week starting Monday Week.
+// Throughput counts the tasks completed in the week and WIP the tasks
+// started but not completed at its end.
+type WeekMetrics struct {
+	Week       string    `json:"week"`
+	Throughput int       `json:"throughput"`
+	WIP        int       `json:"wip"`
+	CycleTime  Durations `json:"cycleTime"`
+	LeadTime   Durations `json:"leadTime"`
+}
+
+// FlowMetrics are a company's flow metrics over its last weeks. Cycle time
+// runs from 🛫 to ✅, lead time from ⏳ to ✅; WIP is the current one.
+type FlowMetrics struct {
+	Company    string        `json:"company"`
+	From       string        `json:"from"`
+	To         string        `json:"to"`
+	Throughput int           `json:"throughput"`
+	WIP        int           `json:"wip"`
+	CycleTime  Durations     `json:"cycleTime"`
+	LeadTime   Durations     `json:"leadTime"`
+	Weeks      []WeekMetrics `json:"weeks"`
+}
+
+// computeFlowMetrics measures the tasks over the given number of weeks,
+// ending with now's week.
+func computeFlowMetrics(company string, tasks []Task, weeks int, now time.Time) FlowMetrics {
+	first := mondayOf(now).AddDate(0, 0, -7*(weeks-1))
+	metrics := FlowMetrics{
+		Company: company,
+		From:    first.Format("2006-01-02"),
+		To:      now.Format("2006-01-02"),
+		WIP:     wipAt(tasks, now.Format("2006-01-02")),
+	}
+
+	var cycle, lead []float64
+	for week := 0; week < weeks; week++ {
+		start := first.AddDate(0, 0, 7*week)
+		last := start.AddDate(0, 0, 6).Format("2006-01-02")
+		weekMetrics := WeekMetrics{Week: start.Format("2006-01-02"), WIP: wipAt(tasks, last)}
+
+		var weekCycle, weekLead []float64
+		for _, task := range tasks {
+			if task.CompletedDate < weekMetrics.Week || task.CompletedDate > last {
+				continue
+			}
+
+			weekMetrics.Throughput++
+			if days, ok := daysTaken(task.StartDate, task.CompletedDate); ok {
+				weekCycle = append(weekCycle, days)
+			}
+			if days, ok := daysTaken(task.ScheduledDate, task.CompletedDate); ok {
+				weekLead = append(weekLead, days)
+			}
+		}
+
+		weekMetrics.CycleTime = summariseDurations(weekCycle)
+		weekMetrics.LeadTime = summariseDurations(weekLead)
+		metrics.Weeks = append(metrics.Weeks, weekMetrics)
+		metrics.Throughput += weekMetrics.Throughput
+		cycle = append(cycle, weekCycle...)
+		lead = append(lead, weekLead...)
+	}
+
+	metrics.CycleTime = summariseDurations(cycle)
+	metrics.LeadTime = summariseDurations(lead)
+	return metrics
+}
+
+// wipAt counts the tasks started by date and not completed by then.
+func wipAt(tasks []Task, date string) int {
+	count := 0
+	for _, task := range tasks {
+		if task.StartDate != "" && task.StartDate <= date && (task.CompletedDate == "" || task.CompletedDate > date) {
+			count++
+		}
+	}
+	return count
+}
+
+// daysTaken returns the days from one date to another, if both parse and
+// they are in order.
+func daysTaken(from, to string) (float64, bool) {
+	start, ok := parseDay(from)
+	if !ok {
+		return 0, false
+	}
+	end, ok := parseDay(to)
+	if !ok || end.Before(start) {
+		return 0, false
+	}
+	return float64(daysBetween(start, end)), true
+}
+
+func summariseDurations(days []float64) Durations {
+	if len(days) == 0 {
+		return Durations{}
+	}
+
+	sorted := slices.Clone(days)
+	slices.Sort(sorted)
+	return Durations{Count: len(sorted), Median: quantile(sorted, 0.5), P90: quantile(sorted, 0.9)}
+}
+
+// quantile interpolates between the closest ranks of sorted values.
+func quantile(sorted []float64, q float64) float64 {
+	position := q * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(position-float64(lower))
+}
+
+// FlowStats measures a configured company's task files over weeks.
+func FlowStats(cfg *config.Config, companyName string, weeks int) (FlowMetrics, error) {
+	company, found := companyNamed(CompaniesFromConfig(cfg.Companies), companyName)
+	if !found {
+		return FlowMetrics{}, fmt.Errorf("unknown company %q", companyName)
+	}
+	if weeks < 1 {
+		return FlowMetrics{}, fmt.Errorf("weeks must be positive, got %d", weeks)
+	}
+
+	index := OpenFileIndex(fileIndexPath())
+	taskPath := filepath.Join(notesPath(), company.FolderPathName, "tasks")
+
+	files, err := readNotes(context.Background(), taskPath, index, cfg.PreferredFileExtension, false, nil)
+	saveIndex(index)
+	if err != nil {
+		return FlowMetrics{}, err
+	}
+
+	var tm TaskManager
+	var tasks []Task
+	for _, file := range files {
+		tasks = append(tasks, tm.CreateTasks(company, file.File.Name, file.Tasks)...)
+	}
+
+	return computeFlowMetrics(company.DisplayName, tasks, weeks, time.Now()), nil
+}
+
+// sparkline draws values as bars scaled to the largest. NaN values are
+// weeks without data and stay blank.
+func sparkline(values []float64) string {
+	highest := 0.0
+	for _, value := range values {
+		if !math.IsNaN(value) && value > highest {
+			highest = value
+		}
+	}
+
+	var line strings.Builder
+	for _, value := range values {
+		switch {
+		case math.IsNaN(value):
+			line.WriteRune(' ')
+		case highest == 0:
+			line.WriteRune(sparkBars[0])
+		default:
+			line.WriteRune(sparkBars[int(value/highest*float64(len(sparkBars)-1))])
+		}
+	}
+	return line.String()
+}
+
+// weekSeries returns a metric of each week, NaN for weeks it does not
+// apply to.
+func weekSeries(weeks []WeekMetrics, value func(WeekMetrics) (float64, bool)) []float64 {
+	series := make([]float64, len(weeks))
+	for i, week := range weeks {
+		if v, ok := value(week); ok {
+			series[i] = v
+		} else {
+			series[i] = math.NaN()
+		}
+	}
+	return series
+}
+
+// renderFlowMetrics renders the stats view: the metrics over the weeks and
+// a sparkline of each, oldest week first.
+func renderFlowMetrics(metrics FlowMetrics, width int) string {
+	title := summaryTitleStyle(width).Render(fmt.Sprintf("Flow · %s · %s to %s", metrics.Company, metrics.From, metrics.To))
+	labelStyle := lipgloss.NewStyle().Width(14).Foreground(summaryTitleColor)
+	valueStyle := lipgloss.NewStyle().Width(28)
+
+	durations := func(d Durations) string {
+		if d.Count == 0 {
+			return "–"
+		}
+		return fmt.Sprintf("median %.1fd · p90 %.1fd", d.Median, d.P90)
+	}
+	cycleMedian := func(w WeekMetrics) (float64, bool) { return w.CycleTime.Median, w.CycleTime.Count > 0 }
+	leadMedian := func(w WeekMetrics) (float64, bool) { return w.LeadTime.Median, w.LeadTime.Count > 0 }
+
+	rows := []struct {
+		label  string
+		value  string
+		series []float64
+		style  lipgloss.Style
+	}{
+		{"Cycle time", durations(metrics.CycleTime), weekSeries(metrics.Weeks, cycleMedian), startedTextStyle},
+		{"Lead time", durations(metrics.LeadTime), weekSeries(metrics.Weeks, leadMedian), scheduledTextStyle},
+		{"Throughput", fmt.Sprintf("%d (%.1f/week)", metrics.Throughput, float64(metrics.Throughput)/float64(max(len(metrics.Weeks), 1))),
+			weekSeries(metrics.Weeks, func(w WeekMetrics) (float64, bool) { return float64(w.Throughput), true }), completedTextStyle},
+		{"WIP", fmt.Sprintf("%d", metrics.WIP),
+			weekSeries(metrics.Weeks, func(w WeekMetrics) (float64, bool) { return float64(w.WIP), true }), overdueTextStyle},
+	}
+
+	lines := []string{title, ""}
+	for _, row := range rows {
+		lines = append(lines, labelStyle.Render(row.label)+valueStyle.Render(row.value)+row.style.Render(sparkline(row.series)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
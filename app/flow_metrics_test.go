@@ -0,0 +1,75 @@
+package app
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestComputeFlowMetrics(t *testing.T) {
+	now := day(2024, 3, 13) // Wednesday
+	tasks := []Task{
+		{StartDate: "2024-03-04", ScheduledDate: "2024-03-01", CompletedDate: "2024-03-06"},
+		{StartDate: "2024-03-05", CompletedDate: "2024-03-05"},
+		{ScheduledDate: "2024-02-28", CompletedDate: "2024-03-08"},
+		{StartDate: "2024-03-07", ScheduledDate: "2024-03-06", CompletedDate: "2024-03-12"},
+		{StartDate: "2024-03-08"},
+		{StartDate: "2024-03-11", CompletedDate: "2024-03-20"},
+		{ScheduledDate: "2024-03-11"},
+		{StartDate: "2024-02-01", CompletedDate: "2024-02-02"},
+	}
+
+	metrics := computeFlowMetrics("Clerky", tasks, 2, now)
+
+	if metrics.From != "2024-03-04" || metrics.To != "2024-03-13" || len(metrics.Weeks) != 2 {
+		t.Fatalf("expected the 2 weeks from Mar 4, got %+v", metrics)
+	}
+
+	first, second := metrics.Weeks[0], metrics.Weeks[1]
+	if first.Throughput != 3 || first.WIP != 2 {
+		t.Errorf("expected 3 completed and 2 in progress the first week, got %+v", first)
+	}
+	if first.CycleTime != (Durations{Count: 2, Median: 1, P90: 1.8}) {
+		t.Errorf("expected cycle times of 2 and 0 days, got %+v", first.CycleTime)
+	}
+	if first.LeadTime != (Durations{Count: 2, Median: 7, P90: 8.6}) {
+		t.Errorf("expected lead times of 5 and 9 days, got %+v", first.LeadTime)
+	}
+
+	if second.Throughput != 1 || second.CycleTime.Median != 5 || second.LeadTime.Median != 6 {
+		t.Errorf("expected 1 task completed in 5 days the second week, got %+v", second)
+	}
+
+	if metrics.Throughput != 4 || metrics.WIP != 2 || metrics.CycleTime.Count != 3 || metrics.CycleTime.Median != 2 {
+		t.Errorf("expected totals over both weeks, got %+v", metrics)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   string
+	}{
+		{[]float64{0, 1, 2, 7}, "▁▂▃█"},
+		{[]float64{0, 0}, "▁▁"},
+		{[]float64{4, math.NaN(), 2}, "█ ▄"},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := sparkline(tt.values); got != tt.want {
+			t.Errorf("sparkline(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestRenderFlowMetrics(t *testing.T) {
+	metrics := computeFlowMetrics("Clerky", []Task{{StartDate: "2024-03-04", CompletedDate: "2024-03-06"}}, 2, day(2024, 3, 13))
+	view := renderFlowMetrics(metrics, 120)
+
+	for _, want := range []string{"Clerky", "median 2.0d", "1 (0.5/week)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected the stats view to contain %q, got\n%s", want, view)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"vision/config"
+	"vision/ical"
+
+	"github.com/charmbracelet/log"
+)
+
+const icsProdID = "-//vision//tasks//EN"
+
+// taskEvents publishes the ⏳ scheduled and 📅 due dates of the open tasks
+// as all-day events. UIDs hash the company, file and task identity, so
+// rescheduling a task moves its event instead of adding one.
+func taskEvents(company Company, files []IndexedFile, extension string) []ical.Event {
+	var events []ical.Event
+
+	eachOpenTask(company, files, func(file IndexedFile, task Task, identity string) {
+		for _, date := range []struct{ kind, value, prefix string }{
+			{"scheduled", task.ScheduledDate, ScheduledIcon + " "},
+			{"due", task.DueDate, DueIcon + " "},
+		} {
+			day, err := time.ParseInLocation("2006-01-02", date.value, time.Local)
+			if err != nil {
+				continue
+			}
+
+			events = append(events, ical.Event{
+				UID:         taskUID(company, file.File.Name, identity, date.kind),
+				Summary:     date.prefix + taskTitle(task),
+				Description: company.DisplayName + " / " + strings.TrimSuffix(file.File.Name, extension),
+				Start:       day,
+				End:         day.AddDate(0, 0, 1),
+				AllDay:      true,
+			})
+		}
+	})
+
+	return events
+}
+
+// eachOpenTask calls fn with the open tasks of the files and an identity
+// that survives rescheduling: the task text without its dates, numbered
+// when a file repeats it. Tasks belong to the company folder, which is
+// where UpdateTask writes.
+func eachOpenTask(company Company, files []IndexedFile, fn func(file IndexedFile, task Task, identity string)) {
+	var tm TaskManager
+
+	for _, file := range files {
+		seen := make(map[string]int)
+
+		for _, task := range tm.CreateTasks(company, file.File.Name, file.Tasks) {
+			if task.Completed || task.IsDone {
+				continue
+			}
+
+			identity := taskTitle(task)
+			seen[identity]++
+			if seen[identity] > 1 {
+				identity += fmt.Sprintf("#%d", seen[identity])
+			}
+
+			fn(file, task, identity)
+		}
+	}
+}
+
+func taskTitle(task Task) string {
+	return strings.TrimSpace(task.Summary())
+}
+
+func taskUID(company Company, file, identity, kind string) string {
+	sum := sha1.Sum([]byte(strings.Join([]string{company.FolderPathName, file, identity, kind}, "\x00")))
+	return hex.EncodeToString(sum[:]) + "@vision"
+}
+
+// encodeTaskEvents writes the task events of the files. DTSTAMP is when the
+// task files were last modified, so the same files give an identical
+// calendar.
+func encodeTaskEvents(w io.Writer, company Company, files []IndexedFile, extension string) (int, error) {
+	events := taskEvents(company, files, extension)
+
+	var stamp time.Time
+	for _, file := range files {
+		if file.File.UpdatedAt.After(stamp) {
+			stamp = file.File.UpdatedAt
+		}
+	}
+
+	return len(events), ical.Encode(w, icsProdID, events, stamp)
+}
+
+// ExportICS writes the scheduled and due tasks of the named company as a
+// calendar and returns the number of events.
+func ExportICS(cfg *config.Config, companyName string, w io.Writer) (int, error) {
+	company, found := companyNamed(CompaniesFromConfig(cfg.Companies), companyName)
+	if !found {
+		return 0, fmt.Errorf("unknown company %q", companyName)
+	}
+
+	index := OpenFileIndex(fileIndexPath())
+	taskPath := filepath.Join(notesPath(), company.FolderPathName, "tasks")
+
+	files, err := readNotes(context.Background(), taskPath, index, cfg.PreferredFileExtension, false, nil)
+	saveIndex(index)
+	if err != nil {
+		return 0, err
+	}
+
+	return encodeTaskEvents(w, company, files, cfg.PreferredFileExtension)
+}
+
+// icsExports remembers the task files each export path was last generated
+// from, so loads only regenerate the exports of companies whose task files
+// changed. Loads run in the background, hence the lock.
+var icsExports = struct {
+	sync.Mutex
+	versions map[string]string
+}{versions: make(map[string]string)}
+
+// taskFilesVersion identifies task files by name and modification time.
+func taskFilesVersion(files []IndexedFile) string {
+	var entries []string
+	for _, file := range files {
+		entries = append(entries, fmt.Sprintf("%s\x00%d", file.File.Name, file.File.UpdatedAt.UnixNano()))
+	}
+
+	slices.Sort(entries)
+	return strings.Join(entries, "\n")
+}
+
+// writeICSExports regenerates the export of every company whose task files
+// changed since its last export. The loaded company's task files are reused,
+// the others are read from the index.
+func writeICSExports(ctx context.Context, root string, companies []Company, loaded Company, files []IndexedFile, index *FileIndex, extension string) {
+	for _, company := range companies {
+		if company.ICSExport == "" {
+			continue
+		}
+
+		taskFiles := files
+		if company.FolderPathName != loaded.FolderPathName {
+			var err error
+			taskFiles, err = readNotes(ctx, filepath.Join(root, company.FolderPathName, "tasks"), index, extension, false, nil)
+			if err != nil {
+				log.Warn("Failed to export calendar", "company", company.DisplayName, "error", err)
+				continue
+			}
+		}
+
+		path := expandHome(company.ICSExport)
+		version := taskFilesVersion(taskFiles)
+
+		icsExports.Lock()
+		unchanged := icsExports.versions[path] == version
+		icsExports.Unlock()
+		if unchanged {
+			continue
+		}
+
+		if err := writeICSExport(company, taskFiles, extension); err != nil {
+			log.Warn("Failed to export calendar", "company", company.DisplayName, "path", path, "error", err)
+			continue
+		}
+
+		icsExports.Lock()
+		icsExports.versions[path] = version
+		icsExports.Unlock()
+	}
+}
+
+// writeICSExport regenerates the company's configured export file from its
+// task files. The file is only rewritten when the calendar changed, so
+// subscribed calendar apps do not reload it needlessly.
+func writeICSExport(company Company, files []IndexedFile, extension string) error {
+	if company.ICSExport == "" {
+		return nil
+	}
+
+	var buffer bytes.Buffer
+	if _, err := encodeTaskEvents(&buffer, company, files, extension); err != nil {
+		return err
+	}
+
+	path := expandHome(company.ICSExport)
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, buffer.Bytes()) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// Write then rename, so subscribers never read a partial calendar
+	temporary := path + ".tmp"
+	if err := os.WriteFile(temporary, buffer.Bytes(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(temporary, path)
+}
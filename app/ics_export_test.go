@@ -0,0 +1,143 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"vision/config"
+	"vision/ical"
+	"vision/utils"
+)
+
+func TestTaskEvents(t *testing.T) {
+	clerky := Company{DisplayName: "Clerky", FolderPathName: "clerky"}
+	files := func(tasks ...string) []IndexedFile {
+		return []IndexedFile{{File: FileInfo{Name: "Release.md"}, Tasks: utils.ExtractTasksFromText(strings.Join(tasks, "\n"))}}
+	}
+
+	events := taskEvents(clerky, files(
+		"- [ ] Ship it ⏳ 2024-03-05 📅 2024-03-08",
+		"- [ ] Ship it ⏳ 2024-03-06",
+		"- [ ] Someday",
+		"- [x] Done ⏳ 2024-03-04 ✅ 2024-03-04",
+	), ".md")
+
+	if len(events) != 3 {
+		t.Fatalf("expected scheduled and due events of the open tasks, got %+v", events)
+	}
+
+	want := []struct {
+		summary string
+		day     int
+	}{{"⏳ Ship it", 5}, {"📅 Ship it", 8}, {"⏳ Ship it", 6}}
+
+	for i, event := range events {
+		if event.Summary != want[i].summary || event.Start.Day() != want[i].day || !event.AllDay || event.Description != "Clerky / Release" {
+			t.Errorf("event %d: unexpected %+v", i, event)
+		}
+	}
+
+	if events[0].UID == events[1].UID || events[0].UID == events[2].UID {
+		t.Errorf("expected distinct UIDs, got %s %s %s", events[0].UID, events[1].UID, events[2].UID)
+	}
+
+	rescheduled := taskEvents(clerky, files("- [ ] Ship it ⏳ 2024-03-12"), ".md")
+	if len(rescheduled) != 1 || rescheduled[0].UID != events[0].UID {
+		t.Errorf("expected rescheduling to keep the UID %s, got %+v", events[0].UID, rescheduled)
+	}
+}
+
+func TestExportICS(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	writeTestNotes(t, filepath.Join(home, "Notes", "clerky", "tasks"), map[string]string{
+		"Release.md": "- [ ] Ship it ⏳ 2024-03-05\n",
+	})
+
+	cfg := &config.Config{
+		Companies:              []config.Company{{DisplayName: "Clerky", FolderPathName: "clerky", ICSExport: "~/calendars/clerky.ics"}},
+		PreferredFileExtension: ".md",
+	}
+
+	var buffer bytes.Buffer
+	count, err := ExportICS(cfg, "clerky", &buffer)
+	if err != nil || count != 1 {
+		t.Fatalf("expected 1 event, got %d %v", count, err)
+	}
+
+	calendar, err := ical.Parse(&buffer)
+	if err != nil || len(calendar.Events) != 1 || calendar.Events[0].Summary != "⏳ Ship it" {
+		t.Fatalf("unexpected calendar %+v %v", calendar, err)
+	}
+
+	if _, err := ExportICS(cfg, "unknown", &buffer); err == nil {
+		t.Errorf("expected an unknown company to fail")
+	}
+
+	// The continuous export only rewrites the file when the calendar changed
+	company := CompaniesFromConfig(cfg.Companies)[0]
+	files := []IndexedFile{{File: FileInfo{Name: "Release.md"}, Tasks: utils.ExtractTasksFromText("- [ ] Ship it ⏳ 2024-03-05")}}
+	path := filepath.Join(home, "calendars", "clerky.ics")
+
+	writeICSExport(company, files, ".md")
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	writeICSExport(company, files, ".md")
+	if info, err := os.Stat(path); err != nil || !info.ModTime().Equal(old) {
+		t.Errorf("expected an unchanged calendar to be kept, got %v %v", info, err)
+	}
+
+	files[0].Tasks[0].Text = " Ship it ⏳ 2024-03-06"
+	writeICSExport(company, files, ".md")
+	if info, err := os.Stat(path); err != nil || info.ModTime().Equal(old) {
+		t.Errorf("expected a changed calendar to be rewritten, got %v %v", info, err)
+	}
+}
+
+func TestWriteICSExportsRegeneratesEveryCompany(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	clerky := Company{DisplayName: "Clerky", FolderPathName: "clerky", ICSExport: "~/calendars/clerky.ics"}
+	qvest := Company{DisplayName: "Qvest.US", FolderPathName: "qvest_us", ICSExport: "~/calendars/qvest.ics"}
+	lifeplus := Company{DisplayName: "Lifeplus", FolderPathName: "lifeplus"}
+	writeTestNotes(t, filepath.Join(home, "Notes", "qvest_us", "tasks"), map[string]string{"Migration.md": "- [ ] Move data ⏳ 2024-03-07\n"})
+
+	files := []IndexedFile{{File: FileInfo{Name: "Release.md", UpdatedAt: time.Now()}, Tasks: utils.ExtractTasksFromText("- [ ] Ship it ⏳ 2024-03-05")}}
+	index := OpenFileIndex(filepath.Join(t.TempDir(), "index.json"))
+	writeICSExports(context.Background(), notesPath(), []Company{clerky, qvest, lifeplus}, clerky, files, index, ".md")
+
+	for _, name := range []string{"clerky.ics", "qvest.ics"} {
+		if _, err := os.Stat(filepath.Join(home, "calendars", name)); err != nil {
+			t.Errorf("expected %s to be exported, got %v", name, err)
+		}
+	}
+
+	// Loads leave the calendars of unchanged task files alone
+	path := filepath.Join(home, "calendars", "clerky.ics")
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	writeICSExports(context.Background(), notesPath(), []Company{clerky, qvest, lifeplus}, clerky, files, index, ".md")
+	if info, err := os.Stat(path); err != nil || !info.ModTime().Equal(old) {
+		t.Errorf("expected the calendar of unchanged task files to be kept, got %v %v", info, err)
+	}
+
+	// An edit regenerates it, stamped with the task file's modification time
+	edited := time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC)
+	files[0].File.UpdatedAt = edited
+	writeICSExports(context.Background(), notesPath(), []Company{clerky, qvest, lifeplus}, clerky, files, index, ".md")
+	if content, err := os.ReadFile(path); err != nil || !strings.Contains(string(content), "DTSTAMP:20240304T093000Z") {
+		t.Errorf("expected the calendar to be stamped with the edit, got %q %v", content, err)
+	}
+}
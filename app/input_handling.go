@@ -54,7 +54,7 @@ func (ih InputHandling) HandleEnter(m *Model) tea.Cmd {
 	}
 
 	if m.IsAddTaskView() {
-		company := m.GetCurrentCompanyName()
+		company := m.DirectoryManager.SelectedCompany
 		input := m.NewTaskInput.Value()
 
 		if err := m.FileManager.CreateTask(company, input); err != nil {
@@ -62,7 +62,7 @@ func (ih InputHandling) HandleEnter(m *Model) tea.Cmd {
 		}
 		return ih.HandleEscape(m)
 	} else if m.IsAddSubTaskView() {
-		company := m.GetCurrentCompanyName()
+		company := m.DirectoryManager.SelectedCompany
 		input := m.NewTaskInput.Value()
 		selectedFile := m.FileManager.SelectedFile
 
@@ -73,15 +73,26 @@ func (ih InputHandling) HandleEnter(m *Model) tea.Cmd {
 			m.Errors = append(m.Errors, err.Error())
 		}
 		return ih.HandleEscape(m)
+	} else if m.IsAddMeetingView() {
+		return MeetingOperations{}.SubmitMeeting(m)
+	} else if m.IsExtractActionItemsView() {
+		return MeetingOperations{}.SubmitActionItems(m)
 	} else if m.IsFilterView() {
+		if err := m.SubmitFilter(m.FilterInput.Value()); err != nil {
+			m.Errors = append(m.Errors, "Filter: "+err.Error())
+			return nil
+		}
+
 		m.ViewManager.IsFilterView = false
-		m.TaskManager.TaskCollection.FilterValue = m.FilterInput.Value()
-		m.TaskManager.TasksCursor = 0
 		m.FilterInput.Blur()
 
 		return nil
 	}
 
+	if link, ok := m.SelectedLink(); ok && m.IsLinkNavigation() {
+		return m.OpenLink(link)
+	}
+
 	m.Select()
 
 	return nil
@@ -98,6 +109,11 @@ func (ih InputHandling) HandleEscape(m *Model) tea.Cmd {
 		return nil
 	}
 
+	if _, ok := m.SelectedLink(); ok && m.IsLinkNavigation() {
+		m.ClearSelectedLink()
+		return nil
+	}
+
 	if m.IsAddTaskView() {
 		m.ViewManager.IsAddTaskView = false
 		m.NewTaskInput.Blur()
@@ -106,6 +122,14 @@ func (ih InputHandling) HandleEscape(m *Model) tea.Cmd {
 		m.ViewManager.IsAddSubTaskView = false
 		m.NewTaskInput.Blur()
 		goToPreviousView = false
+	} else if m.IsAddMeetingView() {
+		m.ViewManager.IsAddMeetingView = false
+		m.NewTaskInput.Blur()
+		goToPreviousView = false
+	} else if m.IsExtractActionItemsView() {
+		m.ViewManager.IsExtractActionItemsView = false
+		m.NewTaskInput.Blur()
+		goToPreviousView = false
 	} else if m.IsFilterView() {
 		m.ViewManager.IsFilterView = false
 		m.FilterInput.Blur()
@@ -116,7 +140,7 @@ func (ih InputHandling) HandleEscape(m *Model) tea.Cmd {
 		goToPreviousView = false
 	}
 
-	m.FileManager.FetchFiles(&m.DirectoryManager, &m.TaskManager)
+	m.FileManager.RequestRefresh()
 
 	if goToPreviousView {
 		m.GoToPreviousView()
@@ -0,0 +1,147 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"vision/config"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+)
+
+// kanbanStatuses are the statuses a task can be moved to on the kanban view.
+var kanbanStatuses = []string{"unscheduled", "scheduled", "started", "completed"}
+
+// KanbanColumn is a board of the kanban view. A task is listed on the first
+// column whose Query matches it, and moving a task onto a column updates it to
+// the column's Status. Columns without a Status are skipped when moving.
+type KanbanColumn struct {
+	Title  string
+	Query  *FilterQuery
+	Status string
+	Color  lipgloss.Color
+}
+
+// defaultKanbanColumns are the inactive, active and completed boards used
+// when no columns are configured.
+var defaultKanbanColumns = []config.KanbanColumn{
+	{Title: "Inactive", Query: "status:unscheduled OR scheduled:>today", Status: "unscheduled"},
+	{Title: "Active", Query: "status:started OR status:scheduled", Status: "scheduled"},
+	{Title: "Completed", Query: "status:completed", Status: "completed"},
+}
+
+// KanbanColumnsFromConfig reads the configured columns, skipping the ones
+// with an invalid query. Without any column the default boards are used.
+func KanbanColumnsFromConfig(columns []config.KanbanColumn) []KanbanColumn {
+	if len(columns) == 0 {
+		columns = defaultKanbanColumns
+	}
+
+	var result []KanbanColumn
+	for _, configured := range columns {
+		query, err := ParseFilterQuery(configured.Query)
+		if err != nil {
+			log.Warn("Skipping kanban column", "title", configured.Title, "query", configured.Query, "error", err)
+			continue
+		}
+
+		status := configured.Status
+		if status != "" && !slices.Contains(kanbanStatuses, status) {
+			log.Warn("Ignoring unknown kanban column status", "title", configured.Title, "status", status)
+			status = ""
+		}
+
+		color := colorForTitle(configured.Title)
+		if configured.Color != "" {
+			color = lipgloss.Color(configured.Color)
+		}
+
+		result = append(result, KanbanColumn{Title: configured.Title, Query: query, Status: status, Color: color})
+	}
+
+	return result
+}
+
+// Boards returns the configured kanban columns, or the default boards when
+// none are set.
+func (vm *ViewManager) Boards() []KanbanColumn {
+	if len(vm.KanbanColumns) == 0 {
+		vm.KanbanColumns = KanbanColumnsFromConfig(nil)
+	}
+	return vm.KanbanColumns
+}
+
+// kanbanColumnIndex returns the first column listing the task, or -1 when no
+// column matches it.
+func kanbanColumnIndex(columns []KanbanColumn, task Task, filename string, fileTags []string, date string) int {
+	return slices.IndexFunc(columns, func(column KanbanColumn) bool {
+		return column.Query.Matches(task, filename, fileTags, date)
+	})
+}
+
+// kanbanMoveTarget returns the nearest column from current in direction (-1
+// for left, 1 for right) that has a status to move a task to.
+func kanbanMoveTarget(columns []KanbanColumn, current int, direction int) (int, bool) {
+	for index := current + direction; index >= 0 && index < len(columns); index += direction {
+		if columns[index].Status != "" {
+			return index, true
+		}
+	}
+	return current, false
+}
+
+// UpdateTaskToStatus applies the status mutation of a kanban column to the
+// task and returns the task as it reads afterwards. Checkbox state and
+// markers left over from the card's previous column are cleared, so the task
+// lands on the status; its ⏳ date is kept.
+func (tm *TaskManager) UpdateTaskToStatus(fm *FileManager, task Task, status string) (Task, error) {
+	var err error
+	switch status {
+	case "unscheduled":
+		err = tm.UpdateTaskToUnscheduled(fm, task)
+	case "scheduled":
+		err = tm.UpdateTaskToScheduled(fm, task)
+	case "started":
+		err = tm.UpdateTaskToStarted(fm, task)
+	case "completed":
+		err = tm.UpdateTaskToCompleted(fm, task)
+	default:
+		return task, fmt.Errorf("unknown task status %q", status)
+	}
+	if err != nil {
+		return task, err
+	}
+
+	today := time.Now().Format("2006-01-02")
+	updated := taskWithLine(task, func(line string) string {
+		return updateTaskLine(line, task, status, today)
+	})
+
+	clear := func(line string) string { return clearKanbanMarkers(line, status) }
+	if err := fm.editTaskLine(updated, clear); err != nil {
+		return updated, err
+	}
+	return taskWithLine(updated, clear), nil
+}
+
+var (
+	startedDateRegex   = regexp.MustCompile(`\s*🛫\s+\d{4}-\d{2}-\d{2}`)
+	completedDateRegex = regexp.MustCompile(`\s*✅\s+\d{4}-\d{2}-\d{2}`)
+)
+
+// clearKanbanMarkers reopens a task moved to any status but completed, and
+// drops its 🛫 date when it is unscheduled.
+func clearKanbanMarkers(line string, status string) string {
+	if status == "completed" {
+		return line
+	}
+
+	line = strings.Replace(completedDateRegex.ReplaceAllString(line, ""), "- [x]", "- [ ]", 1)
+	if status == "unscheduled" {
+		line = startedDateRegex.ReplaceAllString(line, "")
+	}
+	return line
+}
@@ -0,0 +1,228 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+)
+
+// kanbanGroupings are the swimlane groupings the G key cycles through. The
+// empty grouping shows the columns without swimlanes.
+var kanbanGroupings = []string{"", "file", "company", "priority"}
+
+// The priority swimlanes: tasks marked with PriorityIcon, then the others.
+const (
+	priorityLane = "Priority"
+	normalLane   = "Normal"
+)
+
+// kanbanLane is a row of the kanban view holding a file's, company's or
+// priority level's tasks of each column.
+type kanbanLane struct {
+	Title string
+	Lists [][]KanbanItem
+}
+
+// CycleKanbanSwimlanes switches to the next swimlane grouping
+func (vm *ViewManager) CycleKanbanSwimlanes() {
+	index := slices.Index(kanbanGroupings, vm.KanbanSwimlanes)
+	vm.KanbanSwimlanes = kanbanGroupings[(index+1)%len(kanbanGroupings)]
+	vm.KanbanTaskCursor = 0
+}
+
+// kanbanLaneTitle names the swimlane the task of filename belongs to.
+func kanbanLaneTitle(m *Model, grouping string, filename string, task Task) string {
+	switch grouping {
+	case "company":
+		if company, ok := companyNamed(m.DirectoryManager.Companies, task.Company); ok {
+			return company.DisplayName
+		}
+		return task.Company
+	case "priority":
+		if task.Priority != "" {
+			return priorityLane
+		}
+		return normalLane
+	}
+
+	return strings.TrimSuffix(filename, m.FileManager.FileExtension)
+}
+
+// makeKanbanLanes places each task on the first column matching it, within
+// the swimlane of its file, company or priority. File and company lanes
+// follow the order of the files, the priority lane comes first.
+func makeKanbanLanes(m *Model, columns []KanbanColumn, grouping string, keys []string, tasksByFile map[string][]Task, fileTags map[string][]string) []kanbanLane {
+	var lanes []kanbanLane
+
+	for _, key := range keys {
+		for _, task := range tasksByFile[key] {
+			column := kanbanColumnIndex(columns, task, key, fileTags[key], m.TaskManager.DailySummaryDate)
+			if column == -1 {
+				continue
+			}
+
+			title := kanbanLaneTitle(m, grouping, key, task)
+			index := slices.IndexFunc(lanes, func(lane kanbanLane) bool { return lane.Title == title })
+			if index == -1 {
+				lanes = append(lanes, kanbanLane{Title: title, Lists: make([][]KanbanItem, len(columns))})
+				index = len(lanes) - 1
+			}
+
+			lanes[index].Lists[column] = addTaskOrCreateKanbanItem(lanes[index].Lists[column], key, task)
+		}
+	}
+
+	if grouping == "priority" {
+		rank := func(lane kanbanLane) int {
+			if lane.Title == normalLane {
+				return 1
+			}
+			return 0
+		}
+		slices.SortStableFunc(lanes, func(a, b kanbanLane) int { return rank(a) - rank(b) })
+	}
+
+	return lanes
+}
+
+// companyKanbanLanes makes a swimlane for each company, in the order of the
+// sidebar. The selected company's tasks are the loaded ones, the others come
+// from CompanyCollections.
+func companyKanbanLanes(m *Model, columns []KanbanColumn) []kanbanLane {
+	var lanes []kanbanLane
+
+	for _, company := range m.DirectoryManager.Companies {
+		tm := m.TaskManager
+		if company.DisplayName != m.GetCurrentCompanyName() {
+			collection, ok := m.TaskManager.CompanyCollections[company.DisplayName]
+			if !ok {
+				continue
+			}
+			collection.FilterValue = m.TaskManager.TaskCollection.FilterValue
+			tm.TaskCollection = collection
+		}
+
+		tasksByFile := tm.Summary(company.DisplayName)
+		if m.ViewManager.IsWeeklyView {
+			tasksByFile = tm.WeeklySummary(company.DisplayName, tm.WeeklySummaryStartDate, tm.WeeklySummaryEndDate)
+		}
+
+		keys := sortTaskKeys(tasksByFile)
+		slices.Sort(keys)
+		lanes = append(lanes, makeKanbanLanes(m, columns, "company", keys, tasksByFile, tm.TaskCollection.FileTags)...)
+	}
+
+	return lanes
+}
+
+// loadCompanyTasks reads the task files of every company, as the company
+// swimlanes list them all.
+func loadCompanyTasks(ctx context.Context, root string, companies []Company, index *FileIndex, extension string) map[string]TaskCollection {
+	var tm TaskManager
+	collections := make(map[string]TaskCollection)
+	defer saveIndex(index)
+
+	for _, company := range companies {
+		files, err := readNotes(ctx, filepath.Join(root, company.FolderPathName, "tasks"), index, extension, false, nil)
+		if err != nil {
+			log.Warn("Failed to read tasks for the kanban swimlanes", "company", company.DisplayName, "error", err)
+			continue
+		}
+
+		collection := TaskCollection{TasksByFile: make(map[string][]Task)}
+		for _, file := range files {
+			collection.Add(file.File.Name, tm.CreateTasks(company, file.File.Name, file.Tasks))
+			collection.AddFileTags(file.File.Name, file.File.Tags)
+		}
+		collections[company.DisplayName] = collection
+	}
+
+	return collections
+}
+
+// companyTasksCmd loads every company's tasks in the background when the
+// kanban view is grouped by company.
+func (m *Model) companyTasksCmd() tea.Cmd {
+	if m.ViewManager.KanbanSwimlanes != "company" {
+		return nil
+	}
+
+	companies := m.DirectoryManager.Companies
+	index := m.FileManager.Index
+	extension := m.FileManager.FileExtension
+
+	return func() tea.Msg {
+		return CompanyTasksLoadedMsg{Collections: loadCompanyTasks(context.Background(), notesPath(), companies, index, extension)}
+	}
+}
+
+// kanbanLaneColumns joins the lanes back into one list per column, in the
+// order the lanes are shown, so the task cursor walks a column lane by lane.
+func kanbanLaneColumns(lanes []kanbanLane, count int) [][]KanbanItem {
+	lists := make([][]KanbanItem, count)
+	for _, lane := range lanes {
+		for column, items := range lane.Lists {
+			lists[column] = append(lists[column], items...)
+		}
+	}
+	return lists
+}
+
+// renderKanbanLanes renders the column titles followed by a row of cells for
+// each swimlane.
+func renderKanbanLanes(m *Model, columns []KanbanColumn, lanes []kanbanLane) string {
+	count := len(columns)
+	boardWidth := (m.ViewManager.DetailsViewWidth - 2*count) / count
+	cellStyle := lipgloss.NewStyle().Width(boardWidth).MarginRight(2)
+
+	var titles []string
+	for index, column := range columns {
+		title := kanbanBoardTitleStyle(column.Color).Render(column.Title)
+		if index == m.ViewManager.KanbanListCursor {
+			title = highlightedTextStyle.Render("▸ ") + title
+		}
+		titles = append(titles, cellStyle.Render(title))
+	}
+
+	rows := []string{joinHorizontal(titles...), ""}
+	if len(lanes) == 0 {
+		rows = append(rows, loadingTextStyle.Render("No tasks"))
+	}
+
+	// Index of the next task of each column, to find the one under the cursor
+	positions := make([]int, count)
+	for _, lane := range lanes {
+		var cells []string
+
+		for column, items := range lane.Lists {
+			var rendered []string
+			for _, item := range items {
+				if m.ViewManager.KanbanSwimlanes != "file" {
+					rendered = append(rendered, renderFilename(item.filename, boardWidth))
+				}
+				for _, task := range item.tasks {
+					selected := column == m.ViewManager.KanbanListCursor && positions[column] == m.ViewManager.KanbanTaskCursor
+					rendered = append(rendered, renderKanbanTask(task, boardWidth, m.TaskManager.DailySummaryDate, selected, m.ViewManager.IsWeeklyView))
+					positions[column]++
+				}
+			}
+			cells = append(cells, cellStyle.Render(joinVertical(rendered...)))
+		}
+
+		rows = append(rows, taskFileTitleStyle.Render(lane.Title), joinHorizontal(cells...))
+	}
+
+	newViewport := viewport.Model{}
+	newViewport.Width = m.ViewManager.DetailsViewWidth
+	newViewport.Height = m.ViewManager.DetailsViewHeight
+	newViewport.SetContent(joinVertical(rows...))
+	newViewport.LineDown(m.ViewManager.KanbanLineDownAmount())
+
+	return newViewport.View()
+}
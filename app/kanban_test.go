@@ -0,0 +1,224 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"vision/config"
+	"vision/mindmap"
+	"vision/utils"
+)
+
+func testKanbanColumns() []KanbanColumn {
+	return KanbanColumnsFromConfig([]config.KanbanColumn{
+		{Title: "Backlog", Query: "status:unscheduled", Status: "unscheduled"},
+		{Title: "In Progress", Query: "status:started -tag:blocked", Status: "started"},
+		{Title: "Blocked", Query: "tag:blocked status:open"},
+		{Title: "Done", Query: "status:completed", Status: "completed"},
+	})
+}
+
+func TestKanbanColumnsFromConfig(t *testing.T) {
+	columns := KanbanColumnsFromConfig([]config.KanbanColumn{
+		{Title: "Broken", Query: "(status:started"},
+		{Title: "Waiting", Query: "tag:waiting", Status: "paused", Color: "#E84118"},
+	})
+	if len(columns) != 1 || columns[0].Title != "Waiting" || columns[0].Status != "" || columns[0].Color != "#E84118" {
+		t.Errorf("expected only Waiting without a status, got %+v", columns)
+	}
+
+	defaults := KanbanColumnsFromConfig(nil)
+	if len(defaults) != 3 || defaults[0].Title != "Inactive" || defaults[2].Color != completedFileColor {
+		t.Errorf("expected the inactive, active and completed boards, got %+v", defaults)
+	}
+}
+
+func TestMakeKanbanLists(t *testing.T) {
+	m := &Model{TaskManager: TaskManager{DailySummaryDate: "2024-03-05"}}
+	tasks := map[string][]Task{"Release.md": {
+		{Text: " Plan"},
+		{Text: " Ship it #blocked", Started: true, StartDate: "2024-03-04", Tags: []string{"blocked"}},
+		{Text: " Test", Started: true, StartDate: "2024-03-04"},
+		{Text: " Done", Completed: true, CompletedDate: "2024-03-03"},
+	}}
+
+	lists := makeKanbanLists(m, testKanbanColumns(), []string{"Release.md"}, tasks)
+
+	want := []string{"Plan", "Test", "Ship it #blocked", "Done"}
+	for index, title := range want {
+		if len(lists[index]) != 1 || len(lists[index][0].tasks) != 1 || taskTitle(lists[index][0].tasks[0]) != title {
+			t.Errorf("expected column %d to list %q, got %+v", index, title, lists[index])
+		}
+	}
+
+	m.ViewManager.KanbanListCursor = 1
+	setKanbanTasksCounts(lists, m)
+	if m.ViewManager.KanbanTasksCount != 1 || taskTitle(m.TaskManager.SelectedTask) != "Test" {
+		t.Errorf("expected Test to be selected, got %+v", m.TaskManager.SelectedTask)
+	}
+}
+
+func TestKanbanMoveTarget(t *testing.T) {
+	columns := testKanbanColumns()
+
+	if target, ok := kanbanMoveTarget(columns, 1, 1); !ok || target != 3 {
+		t.Errorf("expected to skip the blocked column, got %d", target)
+	}
+	if _, ok := kanbanMoveTarget(columns, 0, -1); ok {
+		t.Errorf("expected no column left of the first one")
+	}
+}
+
+func TestMoveKanbanTask(t *testing.T) {
+	now := time.Now()
+	today := now.Format("2006-01-02")
+	yesterday := now.AddDate(0, 0, -1).Format("2006-01-02")
+	tomorrow := now.AddDate(0, 0, 1).Format("2006-01-02")
+	started := " " + StartedIcon + " " + today
+	completed := " " + CompletedIcon + " " + today
+
+	defaults := KanbanColumnsFromConfig(nil)
+	configured := testKanbanColumns()
+
+	tests := []struct {
+		name       string
+		columns    []KanbanColumn
+		column     int
+		key        string
+		line       string
+		want       string
+		wantColumn int
+	}{
+		{"future card keeps its date", defaults, 0, ">", "- [ ] Ship it ⏳ " + tomorrow, "- [ ] Ship it ⏳ " + tomorrow, 0},
+		{"unscheduled to active", defaults, 0, ">", "- [ ] Ship it", "- [ ] Ship it ⏳ " + today, 1},
+		{"started to inactive", defaults, 1, "<", "- [ ] Ship it ⏳ " + yesterday + " 🛫 " + yesterday, "- [ ] Ship it", 0},
+		{"active to completed", defaults, 1, ">", "- [ ] Ship it ⏳ " + yesterday, "- [x] Ship it ⏳ " + yesterday + completed, 2},
+		{"completed to active", defaults, 2, "<", "- [x] Ship it ⏳ " + yesterday + " ✅ " + yesterday, "- [ ] Ship it ⏳ " + yesterday, 1},
+		{"backlog to in progress", configured, 0, ">", "- [ ] Ship it", "- [ ] Ship it" + started, 1},
+		{"in progress to backlog", configured, 1, "<", "- [ ] Ship it 🛫 " + yesterday, "- [ ] Ship it", 0},
+		{"in progress to done past blocked", configured, 1, ">", "- [ ] Ship it 🛫 " + yesterday, "- [x] Ship it 🛫 " + yesterday + completed, 3},
+		{"done to in progress past blocked", configured, 3, "<", "- [x] Ship it ✅ " + yesterday, "- [ ] Ship it " + started, 1},
+		{"blocked card is not followed", configured, 0, ">", "- [ ] Ship it #blocked", "- [ ] Ship it #blocked" + started, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+
+			taskDir := filepath.Join(home, "Notes", "clerky", "tasks")
+			writeTestNotes(t, taskDir, map[string]string{"Release.md": "# Release\n" + tt.line + "\n"})
+
+			var tm TaskManager
+			content, _ := os.ReadFile(filepath.Join(taskDir, "Release.md"))
+			task := tm.CreateTasks(Company{DisplayName: "Clerky", FolderPathName: "clerky"}, "Release.md", utils.ExtractTasksFromText(string(content)))[0]
+
+			m := &Model{
+				ViewManager: ViewManager{CurrentView: CategoriesView, HideSidebar: true, KanbanColumns: tt.columns, KanbanListCursor: tt.column, KanbanTasksCount: 1},
+				FileManager: FileManager{FileExtension: ".md", Updater: mindmap.NewNullUpdater()},
+				TaskManager: TaskManager{SelectedTask: task, DailySummaryDate: today},
+			}
+
+			TaskOperations{}.HandleKey(tt.key, m)
+
+			content, _ = os.ReadFile(filepath.Join(taskDir, "Release.md"))
+			if got := strings.Split(string(content), "\n")[1]; got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			if m.ViewManager.KanbanListCursor != tt.wantColumn {
+				t.Errorf("expected the cursor on column %d, got %d", tt.wantColumn, m.ViewManager.KanbanListCursor)
+			}
+		})
+	}
+}
+
+func TestMakeKanbanLanes(t *testing.T) {
+	m := &Model{
+		DirectoryManager: DirectoryManager{Companies: []Company{{DisplayName: "Clerky", FolderPathName: "clerky"}}},
+		FileManager:      FileManager{FileExtension: ".md"},
+		TaskManager:      TaskManager{DailySummaryDate: "2024-03-05"},
+	}
+	tasks := map[string][]Task{
+		"Release.md": {
+			{Text: " Plan", Company: "clerky"},
+			{Text: " 🔺 Ship it", Priority: "Ship", Company: "clerky", Started: true, StartDate: "2024-03-04"},
+		},
+		"Migration.md": {
+			{Text: " Move data", Company: "qvest_us", Completed: true, CompletedDate: "2024-03-04"},
+		},
+	}
+	keys := []string{"Release.md", "Migration.md"}
+	columns := testKanbanColumns()
+
+	titles := func(lanes []kanbanLane) string {
+		var names []string
+		for _, lane := range lanes {
+			names = append(names, lane.Title)
+		}
+		return strings.Join(names, ", ")
+	}
+
+	if lanes := makeKanbanLanes(m, columns, "file", keys, tasks, nil); titles(lanes) != "Release, Migration" || len(lanes[0].Lists[0]) != 1 || len(lanes[0].Lists[1]) != 1 {
+		t.Errorf("expected a lane per file across the columns, got %+v", lanes)
+	}
+	if lanes := makeKanbanLanes(m, columns, "company", keys, tasks, nil); titles(lanes) != "Clerky, qvest_us" {
+		t.Errorf("expected a lane per company, got %q", titles(lanes))
+	}
+
+	lanes := makeKanbanLanes(m, columns, "priority", keys, tasks, nil)
+	if titles(lanes) != "Priority, Normal" {
+		t.Errorf("expected the priority lane before the normal one, got %q", titles(lanes))
+	}
+
+	// The cursor walks the In Progress column lane by lane
+	m.ViewManager.KanbanListCursor = 1
+	setKanbanTasksCounts(kanbanLaneColumns(lanes, len(columns)), m)
+	if m.ViewManager.KanbanTasksCount != 1 || taskTitle(m.TaskManager.SelectedTask) != "🔺 Ship it" {
+		t.Errorf("expected Ship it to be selected, got %+v", m.TaskManager.SelectedTask)
+	}
+}
+
+func TestCycleKanbanSwimlanes(t *testing.T) {
+	vm := ViewManager{KanbanTaskCursor: 2}
+
+	var groupings []string
+	for range kanbanGroupings {
+		vm.CycleKanbanSwimlanes()
+		groupings = append(groupings, vm.KanbanSwimlanes)
+	}
+
+	if strings.Join(groupings, ",") != "file,company,priority," || vm.KanbanTaskCursor != 0 {
+		t.Errorf("expected to cycle through the groupings back to none, got %q", groupings)
+	}
+}
+
+func TestCompanyKanbanLanesListEveryCompany(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	clerky := Company{DisplayName: "Clerky", FolderPathName: "clerky"}
+	qvest := Company{DisplayName: "Qvest.US", FolderPathName: "qvest_us"}
+	writeTestNotes(t, filepath.Join(home, "Notes", "clerky", "tasks"), map[string]string{"Release.md": "- [ ] Ship it 🛫 2024-03-04\n"})
+	writeTestNotes(t, filepath.Join(home, "Notes", "qvest_us", "tasks"), map[string]string{"Migration.md": "- [ ] Move data 🛫 2024-03-04\n"})
+
+	index := OpenFileIndex(filepath.Join(t.TempDir(), "index.json"))
+	collections := loadCompanyTasks(context.Background(), notesPath(), []Company{clerky, qvest}, index, ".md")
+
+	m := &Model{
+		DirectoryManager: DirectoryManager{Companies: []Company{clerky, qvest}, SelectedCompany: clerky},
+		FileManager:      FileManager{FileExtension: ".md"},
+		TaskManager:      TaskManager{DailySummaryDate: "2024-03-05", TaskCollection: collections["Clerky"], CompanyCollections: collections},
+		ViewManager:      ViewManager{KanbanSwimlanes: "company"},
+	}
+
+	lanes := companyKanbanLanes(m, testKanbanColumns())
+	if len(lanes) != 2 || lanes[0].Title != "Clerky" || lanes[1].Title != "Qvest.US" {
+		t.Fatalf("expected a lane per company, got %+v", lanes)
+	}
+	if items := lanes[1].Lists[1]; len(items) != 1 || taskTitle(items[0].tasks[0]) != "Move data" {
+		t.Errorf("expected Move data in progress on the Qvest.US lane, got %+v", items)
+	}
+}
@@ -16,13 +16,14 @@ func NewKeyCommandFactory() *KeyCommandFactory {
 	registry.Register("l", LKeyCommand{})
 	registry.Register("g", GKeyCommand{})
 	registry.Register("tab", TabKeyCommand{})
-	registry.Register("shift + tab", ShiftTabKeyCommand{})
+	registry.Register("shift+tab", ShiftTabKeyCommand{})
 
 	// File operations
 	registry.Register("e", EKeyCommand{})
 	registry.Register("o", OKeyCommand{})
 	registry.Register("n", NKeyCommand{})
 	registry.Register("f", FKeyCommand{})
+	registry.Register("F", UppercaseFKeyCommand{})
 
 	// Task operations
 	registry.Register("d", DKeyCommand{})
@@ -32,11 +33,19 @@ func NewKeyCommandFactory() *KeyCommandFactory {
 	registry.Register("S", UppercaseSKeyCommand{})
 	registry.Register("a", AKeyCommand{})
 	registry.Register("A", UppercaseAKeyCommand{})
+	registry.Register("<", LessThanKeyCommand{})
+	registry.Register(">", GreaterThanKeyCommand{})
 
 	// View control
 	registry.Register("c", CKeyCommand{})
+	registry.Register("T", UppercaseTKeyCommand{})
+	registry.Register("G", UppercaseGKeyCommand{})
+	registry.Register("H", UppercaseHKeyCommand{})
+	registry.Register("I", UppercaseIKeyCommand{})
+	registry.Register("P", UppercasePKeyCommand{})
 	registry.Register("w", WKeyCommand{})
 	registry.Register("W", UppercaseWKeyCommand{})
+	registry.Register("0", ZeroKeyCommand{})
 	registry.Register("1", OneKeyCommand{})
 	registry.Register("2", TwoKeyCommand{})
 	registry.Register("3", ThreeKeyCommand{})
@@ -53,6 +62,13 @@ func NewKeyCommandFactory() *KeyCommandFactory {
 	registry.Register("t", TKeyCommand{})
 	registry.Register("m", MKeyCommand{})
 
+	// Meetings
+	registry.Register("M", UppercaseMKeyCommand{})
+	registry.Register("X", UppercaseXKeyCommand{})
+
+	// Sync
+	registry.Register("R", UppercaseRKeyCommand{})
+
 	return &KeyCommandFactory{
 		registry: registry,
 	}
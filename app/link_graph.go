@@ -0,0 +1,226 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+)
+
+// BacklinkLink is the kind of the links listed under a note's content, to the
+// notes linking to it.
+const BacklinkLink = "backlink"
+
+// Backlink is a note that links to another note.
+type Backlink struct {
+	Company  Company
+	Category string
+	File     FileInfo
+}
+
+// LinkGraph maps notes to the notes linking to them. Links are resolved to
+// the path of the note they target under root, the way following them does;
+// links to notes that don't exist are kept by note name.
+type LinkGraph struct {
+	root      string
+	companies []Company
+	backlinks map[string][]Backlink
+}
+
+func NewLinkGraph() *LinkGraph {
+	return &LinkGraph{backlinks: make(map[string][]Backlink)}
+}
+
+func linkGraphKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Add records the note targets a file links to. A file linking to the same
+// note twice is listed once.
+func (g *LinkGraph) Add(company Company, category string, file FileInfo, targets []string, extension string) {
+	g.add(cachedReadDir(), company, category, file, targets, extension)
+}
+
+func (g *LinkGraph) add(readDir func(string) ([]os.DirEntry, error), company Company, category string, file FileInfo, targets []string, extension string) {
+	seen := make(map[string]bool)
+
+	for _, target := range targets {
+		key := g.targetKey(readDir, company, category, strings.TrimSuffix(target, extension), extension)
+		if key == "" || seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		g.backlinks[key] = append(g.backlinks[key], Backlink{Company: company, Category: category, File: file})
+	}
+}
+
+// targetKey is the path of the note a link of the company's category
+// targets, preferring the company's notes, or the note name when no note
+// matches.
+func (g *LinkGraph) targetKey(readDir func(string) ([]os.DirEntry, error), company Company, category string, target string, extension string) string {
+	if g.root != "" {
+		if targetCompany, folder, name, ok := resolveNoteLinkIn(readDir, g.root, g.companies, company, category, target, extension); ok {
+			return filepath.Join(g.root, targetCompany.FolderPathName, folder, name)
+		}
+	}
+	return linkGraphKey(target)
+}
+
+// cachedReadDir lists each folder once, so resolving the links of many notes
+// doesn't read the same folders again.
+func cachedReadDir() func(string) ([]os.DirEntry, error) {
+	listed := make(map[string][]os.DirEntry)
+
+	return func(path string) ([]os.DirEntry, error) {
+		if entries, ok := listed[path]; ok {
+			return entries, nil
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		listed[path] = entries
+		return entries, nil
+	}
+}
+
+// Replace swaps the links of a company's category for the ones of files, so
+// the graph follows the folders read by each load. Categories that are not
+// among the company's subfolders are not part of the graph.
+func (g *LinkGraph) Replace(company Company, category string, files []IndexedFile, extension string) {
+	if g == nil || !slices.ContainsFunc(company.SubFolders, func(folder string) bool { return strings.EqualFold(folder, category) }) {
+		return
+	}
+
+	category = strings.ToLower(category)
+	for key, backlinks := range g.backlinks {
+		backlinks = slices.DeleteFunc(backlinks, func(backlink Backlink) bool {
+			return backlink.Company.FolderPathName == company.FolderPathName && backlink.Category == category
+		})
+		if len(backlinks) == 0 {
+			delete(g.backlinks, key)
+		} else {
+			g.backlinks[key] = backlinks
+		}
+	}
+
+	readDir := cachedReadDir()
+	for _, file := range files {
+		g.add(readDir, company, category, file.File, file.Links, extension)
+	}
+}
+
+// Backlinks returns the notes linking to file, most recently updated first.
+func (g *LinkGraph) Backlinks(file FileInfo, extension string) []Backlink {
+	if g == nil || file.Name == "" {
+		return nil
+	}
+
+	linking := append(slices.Clone(g.backlinks[filepath.Clean(file.FullPath)]), g.backlinks[linkGraphKey(strings.TrimSuffix(file.Name, extension))]...)
+
+	var backlinks []Backlink
+	for _, backlink := range linking {
+		if backlink.File.FullPath != file.FullPath {
+			backlinks = append(backlinks, backlink)
+		}
+	}
+
+	slices.SortStableFunc(backlinks, func(a, b Backlink) int { return updatedAtCmp(a.File, b.File) })
+
+	return backlinks
+}
+
+// buildLinkGraph reads the links of every note of the companies. Unchanged
+// notes are taken from the index, so only edited notes are parsed again.
+// Subfolders missing on disk are skipped.
+func buildLinkGraph(ctx context.Context, root string, companies []Company, index *FileIndex, extension string) (*LinkGraph, error) {
+	graph := NewLinkGraph()
+	graph.root, graph.companies = root, companies
+	readDir := cachedReadDir()
+
+	for _, company := range companies {
+		for _, category := range company.SubFolders {
+			category = strings.ToLower(category)
+			path := filepath.Join(root, company.FolderPathName, category)
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+
+			files, err := readNotes(ctx, path, index, extension, false, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, file := range files {
+				graph.add(readDir, company, category, file.File, file.Links, extension)
+			}
+		}
+	}
+
+	return graph, nil
+}
+
+// ApplyLinkGraph stores the graph built on the first load. Later loads keep
+// it up to date with Replace. A failed build is retried on the next load.
+func (fm *FileManager) ApplyLinkGraph(msg LinkGraphBuiltMsg) bool {
+	if msg.Err != nil {
+		log.Warn("Failed to build the link graph", "error", msg.Err)
+		fm.linkGraphRequested = false
+		return false
+	}
+
+	if fm.LinkGraph != nil {
+		return false
+	}
+
+	fm.LinkGraph = msg.Graph
+	return true
+}
+
+// backlinkLinks lists the backlinks of the selected file as links, so tab
+// moves on to them after the links in the content.
+func (m *Model) backlinkLinks() []NoteLink {
+	var links []NoteLink
+
+	for _, backlink := range m.FileManager.LinkGraph.Backlinks(m.FileManager.SelectedFile, m.FileManager.FileExtension) {
+		label := backlink.Category + "/" + backlink.File.FileNameWithoutExtension(m.FileManager.FileExtension)
+		if backlink.Company.DisplayName != m.GetCurrentCompanyName() {
+			label += " (" + backlink.Company.DisplayName + ")"
+		}
+
+		backlink := backlink
+		links = append(links, NoteLink{Kind: BacklinkLink, Target: backlink.File.Name, Label: label, Start: -1, End: -1, Backlink: &backlink})
+	}
+
+	return links
+}
+
+// renderBacklinks lists the notes linking to the selected file under its
+// content, highlighting the selected one.
+func renderBacklinks(links []NoteLink, selected NoteLink, hasSelection bool) string {
+	var items []string
+
+	for _, link := range links {
+		if link.Kind != BacklinkLink {
+			continue
+		}
+
+		if hasSelection && selected.Backlink != nil && selected.Backlink.File.FullPath == link.Backlink.File.FullPath {
+			items = append(items, highlightedTextStyle.Render(linkMarker+" "+link.Label))
+		} else {
+			items = append(items, defaultTextStyle.Render("  "+link.Label))
+		}
+	}
+
+	if len(items) == 0 {
+		return ""
+	}
+
+	return lipgloss.NewStyle().PaddingLeft(2).Render(joinVertical(suggestionTitleStyle.Render("Backlinks"), joinVertical(items...)))
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBuildLinkGraphFindsBacklinksAcrossCompanies(t *testing.T) {
+	root := t.TempDir()
+	clerky := Company{DisplayName: "Clerky", FolderPathName: "clerky", SubFolders: []string{"people", "meetings"}}
+	qvest := Company{DisplayName: "Qvest.US", FolderPathName: "qvest_us", SubFolders: []string{"meetings"}}
+	writeTestNotes(t, filepath.Join(root, "clerky", "people"), map[string]string{"Jane Doe.md": "See [[Jane Doe]] and [[John Roe]]"})
+	writeTestNotes(t, filepath.Join(root, "clerky", "meetings"), map[string]string{
+		"planning.md": "With [[Jane Doe|Jane]] and [[jane doe]]",
+		"retro.md":    "Nobody",
+	})
+	writeTestNotes(t, filepath.Join(root, "qvest_us", "meetings"), map[string]string{"kickoff.md": "Ask [Jane](../people/Jane%20Doe.md)"})
+
+	older := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(filepath.Join(root, "clerky", "meetings", "planning.md"), older, older); err != nil {
+		t.Fatal(err)
+	}
+
+	index := OpenFileIndex(filepath.Join(t.TempDir(), "index.json"))
+	graph, err := buildLinkGraph(context.Background(), root, []Company{clerky, qvest}, index, ".md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jane := FileInfo{Name: "Jane Doe.md", FullPath: filepath.Join(root, "clerky", "people", "Jane Doe.md")}
+	backlinks := graph.Backlinks(jane, ".md")
+
+	tests := []struct {
+		company  string
+		category string
+		file     string
+	}{
+		{"Qvest.US", "meetings", "kickoff.md"},
+		{"Clerky", "meetings", "planning.md"},
+	}
+
+	if len(backlinks) != len(tests) {
+		t.Fatalf("expected %d backlinks without the note itself, got %+v", len(tests), backlinks)
+	}
+
+	for i, tt := range tests {
+		if backlinks[i].Company.DisplayName != tt.company || backlinks[i].Category != tt.category || backlinks[i].File.Name != tt.file {
+			t.Errorf("backlink %d: expected %s/%s/%s, got %s/%s/%s", i, tt.company, tt.category, tt.file,
+				backlinks[i].Company.DisplayName, backlinks[i].Category, backlinks[i].File.Name)
+		}
+	}
+
+	if entry := indexNote("[[A]] https://example.com [b](B.md)"); len(entry.Links) != 2 || entry.Links[0] != "A" || entry.Links[1] != "B.md" {
+		t.Errorf("expected the note links to be indexed, got %v", entry.Links)
+	}
+}
+
+func TestLinkGraphReplaceUpdatesLoadedFolder(t *testing.T) {
+	root := t.TempDir()
+	clerky := Company{DisplayName: "Clerky", FolderPathName: "clerky", SubFolders: []string{"people", "meetings", "ideas"}}
+	writeTestNotes(t, filepath.Join(root, "clerky", "meetings"), map[string]string{"planning.md": "With [[Jane Doe]]"})
+	writeTestNotes(t, filepath.Join(root, "clerky", "people"), map[string]string{"Jane Doe.md": "Jane"})
+
+	index := OpenFileIndex(filepath.Join(t.TempDir(), "index.json"))
+	graph, err := buildLinkGraph(context.Background(), root, []Company{clerky}, index, ".md")
+	if err != nil {
+		t.Fatalf("expected the missing ideas folder to be skipped, got %v", err)
+	}
+
+	jane := FileInfo{Name: "Jane Doe.md", FullPath: filepath.Join(root, "clerky", "people", "Jane Doe.md")}
+	john := FileInfo{Name: "John Roe.md", FullPath: filepath.Join(root, "clerky", "people", "John Roe.md")}
+	if backlinks := graph.Backlinks(jane, ".md"); len(backlinks) != 1 {
+		t.Fatalf("expected one backlink to Jane before the edit, got %+v", backlinks)
+	}
+
+	writeTestNotes(t, filepath.Join(root, "clerky", "meetings"), map[string]string{"planning.md": "With [[John Roe]]"})
+	files, err := readNotes(context.Background(), filepath.Join(root, "clerky", "meetings"), index, ".md", false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	graph.Replace(clerky, "Meetings", files, ".md")
+	if backlinks := graph.Backlinks(jane, ".md"); len(backlinks) != 0 {
+		t.Errorf("expected the edited note to no longer link to Jane, got %+v", backlinks)
+	}
+	if backlinks := graph.Backlinks(john, ".md"); len(backlinks) != 1 || backlinks[0].Category != "meetings" {
+		t.Errorf("expected the edited note to link to John, got %+v", backlinks)
+	}
+
+	graph.Replace(clerky, "standups", files, ".md")
+	if backlinks := graph.Backlinks(john, ".md"); len(backlinks) != 1 {
+		t.Errorf("expected folders outside the subfolders to be ignored, got %+v", backlinks)
+	}
+}
+
+func TestLinkGraphKeysBacklinksByResolvedNote(t *testing.T) {
+	root := t.TempDir()
+	clerky := Company{DisplayName: "Clerky", FolderPathName: "clerky", SubFolders: []string{"people", "meetings"}}
+	qvest := Company{DisplayName: "Qvest.US", FolderPathName: "qvest_us", SubFolders: []string{"people", "meetings"}}
+	for _, company := range []string{"clerky", "qvest_us"} {
+		writeTestNotes(t, filepath.Join(root, company, "people"), map[string]string{"Jane Doe.md": "Jane"})
+		writeTestNotes(t, filepath.Join(root, company, "meetings"), map[string]string{company + " sync.md": "With [[Jane Doe]]"})
+	}
+
+	index := OpenFileIndex(filepath.Join(t.TempDir(), "index.json"))
+	graph, err := buildLinkGraph(context.Background(), root, []Company{clerky, qvest}, index, ".md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, company := range []Company{clerky, qvest} {
+		jane := FileInfo{Name: "Jane Doe.md", FullPath: filepath.Join(root, company.FolderPathName, "people", "Jane Doe.md")}
+		backlinks := graph.Backlinks(jane, ".md")
+		if len(backlinks) != 1 || backlinks[0].Company.FolderPathName != company.FolderPathName {
+			t.Errorf("expected only the %s meeting to link to its Jane Doe, got %+v", company.DisplayName, backlinks)
+		}
+	}
+}
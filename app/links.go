@@ -0,0 +1,306 @@
+package app
+
+import (
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	WikiLink     = "wikilink"
+	MarkdownLink = "markdown"
+	URLLink      = "url"
+
+	defaultLinkOpener = "xdg-open"
+
+	// linkMarker prefixes the selected link so it can be found again in the
+	// rendered markdown.
+	linkMarker = "▸"
+)
+
+var (
+	wikilinkPattern     = regexp.MustCompile(`\[\[([^\[\]|]+?)(?:\|([^\[\]]+?))?\]\]`)
+	markdownLinkPattern = regexp.MustCompile(`\[([^\[\]]+)\]\(([^()\s]+)\)`)
+	bareURLPattern      = regexp.MustCompile(`https?://[^\s<>()\[\]` + "`" + `]+`)
+)
+
+// NoteLink is a link in a note's content. Wikilinks, and markdown links to
+// local notes, target a note name; the others target a URL. Backlinks are
+// listed under the content and have no position in it.
+type NoteLink struct {
+	Kind     string
+	Target   string
+	Label    string
+	Start    int
+	End      int
+	Backlink *Backlink
+}
+
+func (l NoteLink) IsNote() bool {
+	return l.Kind == WikiLink
+}
+
+// LinkState tracks the link selected in the note shown in the details view.
+// The selection belongs to FilePath and is dropped once another note shows.
+type LinkState struct {
+	FilePath string
+	Cursor   int
+	Opener   string
+}
+
+// extractLinks finds the links in content in the order they appear. Links
+// inside wikilinks or markdown links are not reported twice.
+func extractLinks(content string) []NoteLink {
+	var links []NoteLink
+
+	overlaps := func(start, end int) bool {
+		for _, link := range links {
+			if start < link.End && end > link.Start {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, match := range wikilinkPattern.FindAllStringSubmatchIndex(content, -1) {
+		target := noteLinkTarget(content[match[2]:match[3]])
+		label := target
+		if match[4] >= 0 {
+			label = strings.TrimSpace(content[match[4]:match[5]])
+		}
+
+		links = append(links, NoteLink{Kind: WikiLink, Target: target, Label: label, Start: match[0], End: match[1]})
+	}
+
+	for _, match := range markdownLinkPattern.FindAllStringSubmatchIndex(content, -1) {
+		if overlaps(match[0], match[1]) {
+			continue
+		}
+
+		link := NoteLink{Kind: MarkdownLink, Target: content[match[4]:match[5]], Label: content[match[2]:match[3]], Start: match[0], End: match[1]}
+		if !isURL(link.Target) {
+			target, err := url.PathUnescape(link.Target)
+			if err != nil {
+				target = link.Target
+			}
+			link.Kind = WikiLink
+			link.Target = noteLinkTarget(target)
+		}
+
+		links = append(links, link)
+	}
+
+	for _, match := range bareURLPattern.FindAllStringIndex(content, -1) {
+		target := strings.TrimRight(content[match[0]:match[1]], ".,;:!?'\"")
+		end := match[0] + len(target)
+
+		if overlaps(match[0], end) {
+			continue
+		}
+
+		links = append(links, NoteLink{Kind: URLLink, Target: target, Label: target, Start: match[0], End: end})
+	}
+
+	slices.SortFunc(links, func(a, b NoteLink) int { return a.Start - b.Start })
+
+	return links
+}
+
+func isURL(target string) bool {
+	return strings.Contains(target, "://") || strings.HasPrefix(target, "mailto:")
+}
+
+// noteLinkTarget reduces a link target to a note name, dropping folders and
+// heading or block references.
+func noteLinkTarget(target string) string {
+	if index := strings.IndexAny(target, "#^"); index >= 0 {
+		target = target[:index]
+	}
+
+	return strings.TrimSpace(path.Base(filepath.ToSlash(strings.TrimSpace(target))))
+}
+
+// resolveNoteLink finds the note a link targets. The selected category wins
+// over the rest of the company, which wins over the other companies.
+func resolveNoteLink(root string, companies []Company, current Company, category string, target string, extension string) (Company, string, string, bool) {
+	return resolveNoteLinkIn(os.ReadDir, root, companies, current, category, target, extension)
+}
+
+// resolveNoteLinkIn resolves a link like resolveNoteLink, listing the folders
+// with readDir.
+func resolveNoteLinkIn(readDir func(string) ([]os.DirEntry, error), root string, companies []Company, current Company, category string, target string, extension string) (Company, string, string, bool) {
+	if target == "" || target == "." {
+		return Company{}, "", "", false
+	}
+
+	ordered := []Company{current}
+	for _, company := range companies {
+		if company.DisplayName != current.DisplayName {
+			ordered = append(ordered, company)
+		}
+	}
+
+	for _, company := range ordered {
+		folders := company.SubFolders
+		if company.DisplayName == current.DisplayName && slices.Contains(folders, category) {
+			folders = append([]string{category}, slices.DeleteFunc(slices.Clone(folders), func(folder string) bool { return folder == category })...)
+		}
+
+		for _, folder := range folders {
+			entries, err := readDir(filepath.Join(root, company.FolderPathName, folder))
+			if err != nil {
+				continue
+			}
+
+			for _, entry := range entries {
+				name := entry.Name()
+				if !entry.IsDir() && (strings.EqualFold(name, target+extension) || (strings.EqualFold(name, target) && strings.HasSuffix(name, extension))) {
+					return company, folder, name, true
+				}
+			}
+		}
+	}
+
+	return Company{}, "", "", false
+}
+
+// highlightLink marks the selected link in the note's markdown so it renders
+// as a highlighted code span.
+func highlightLink(content string, link NoteLink) string {
+	if link.Start < 0 || link.End > len(content) || link.Start > link.End {
+		return content
+	}
+
+	label := strings.ReplaceAll(link.Label, "`", "'")
+	return content[:link.Start] + "`" + linkMarker + " " + label + "`" + content[link.End:]
+}
+
+// scrollToLink scrolls the viewport to the highlighted link when it is out
+// of view.
+func scrollToLink(vp *viewport.Model, rendered string) {
+	for line, text := range strings.Split(rendered, "\n") {
+		if !strings.Contains(text, linkMarker) {
+			continue
+		}
+
+		if line < vp.YOffset || line >= vp.YOffset+vp.Height {
+			vp.SetYOffset(line - vp.Height/2)
+		}
+		return
+	}
+}
+
+// openURL opens a URL with the configured opener. The opener is started
+// rather than run in the terminal, so the app keeps the screen.
+func openURL(opener string, target string) tea.Cmd {
+	return func() tea.Msg {
+		args := strings.Fields(opener)
+		if len(args) == 0 {
+			args = []string{defaultLinkOpener}
+		}
+
+		c := exec.Command(args[0], append(args[1:], target)...)
+		if err := c.Start(); err != nil {
+			return ErrorOccurredMsg{Err: err, Context: "opening " + target}
+		}
+
+		go c.Wait()
+		return nil
+	}
+}
+
+// IsLinkNavigation reports whether tab moves between the links of the note
+// in the details view rather than between suggestions.
+func (m *Model) IsLinkNavigation() bool {
+	return m.IsItemDetailsFocus() && !m.IsTaskDetailsFocus() && !m.IsNewTaskInputView() &&
+		!m.IsFilterView() && !m.IsSearchView()
+}
+
+// NoteLinks returns the links of the selected note, once its content loaded,
+// followed by its backlinks.
+func (m *Model) NoteLinks() []NoteLink {
+	content, ok := m.FileManager.FileContent(m.FileManager.SelectedFile)
+	if !ok {
+		return nil
+	}
+
+	return append(extractLinks(content), m.backlinkLinks()...)
+}
+
+func (m *Model) SelectedLink() (NoteLink, bool) {
+	if m.Links.FilePath == "" || m.Links.FilePath != m.FileManager.SelectedFile.FullPath {
+		return NoteLink{}, false
+	}
+
+	links := m.NoteLinks()
+	if m.Links.Cursor < 0 || m.Links.Cursor >= len(links) {
+		return NoteLink{}, false
+	}
+
+	return links[m.Links.Cursor], true
+}
+
+func (m *Model) GoToNextLink() {
+	m.moveLinkCursor(1)
+}
+
+func (m *Model) GoToPreviousLink() {
+	m.moveLinkCursor(-1)
+}
+
+func (m *Model) moveLinkCursor(step int) {
+	links := m.NoteLinks()
+	if len(links) == 0 {
+		return
+	}
+
+	if _, ok := m.SelectedLink(); !ok {
+		m.Links.FilePath = m.FileManager.SelectedFile.FullPath
+		m.Links.Cursor = 0
+		if step < 0 {
+			m.Links.Cursor = len(links) - 1
+		}
+		return
+	}
+
+	m.Links.Cursor = (m.Links.Cursor + step + len(links)) % len(links)
+}
+
+func (m *Model) ClearSelectedLink() {
+	m.Links.FilePath = ""
+	m.Links.Cursor = 0
+}
+
+// OpenLink follows a link: notes open in the details view, anything else
+// goes to the configured opener.
+func (m *Model) OpenLink(link NoteLink) tea.Cmd {
+	if link.Backlink != nil {
+		m.ClearSelectedLink()
+		m.OpenFile(link.Backlink.Company, link.Backlink.Category, link.Backlink.File.Name)
+		return nil
+	}
+
+	if !link.IsNote() {
+		return openURL(m.Links.Opener, link.Target)
+	}
+
+	company, category, filename, ok := resolveNoteLink(notesPath(), m.DirectoryManager.Companies, m.DirectoryManager.SelectedCompany,
+		strings.ToLower(m.DirectoryManager.SelectedCategory), link.Target, m.FileManager.FileExtension)
+	if !ok {
+		m.Errors = append(m.Errors, "No note named "+link.Target)
+		return nil
+	}
+
+	m.ClearSelectedLink()
+	m.OpenFile(company, category, filename)
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractLinks(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []NoteLink
+	}{
+		{
+			name:    "wikilink with alias",
+			content: "Met [[Jane Doe|Jane]] today",
+			want:    []NoteLink{{Kind: WikiLink, Target: "Jane Doe", Label: "Jane", Start: 4, End: 21}},
+		},
+		{
+			name:    "wikilink with heading",
+			content: "[[people/Jane Doe#Goals]]",
+			want:    []NoteLink{{Kind: WikiLink, Target: "Jane Doe", Label: "Jane Doe", Start: 0, End: 25}},
+		},
+		{
+			name:    "markdown links and bare URL in order",
+			content: "See https://example.com/a. and [spec](../projects/api%20spec.md) or [docs](https://docs.example.com)",
+			want: []NoteLink{
+				{Kind: URLLink, Target: "https://example.com/a", Label: "https://example.com/a", Start: 4, End: 25},
+				{Kind: WikiLink, Target: "api spec.md", Label: "spec", Start: 31, End: 64},
+				{Kind: MarkdownLink, Target: "https://docs.example.com", Label: "docs", Start: 68, End: 100},
+			},
+		},
+		{
+			name:    "no links",
+			content: "- [ ] Plain task",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractLinks(tt.content)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d links, got %+v", len(tt.want), got)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("link %d: expected %+v, got %+v", i, tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestResolveNoteLink(t *testing.T) {
+	root := t.TempDir()
+	clerky := Company{DisplayName: "Clerky", FolderPathName: "clerky", SubFolders: []string{"people", "meetings"}}
+	qvest := Company{DisplayName: "Qvest.US", FolderPathName: "qvest_us", SubFolders: []string{"people"}}
+	writeTestNotes(t, filepath.Join(root, "clerky", "people"), map[string]string{"Jane Doe.md": ""})
+	writeTestNotes(t, filepath.Join(root, "clerky", "meetings"), map[string]string{"jane doe.md": ""})
+	writeTestNotes(t, filepath.Join(root, "qvest_us", "people"), map[string]string{"John Roe.md": ""})
+
+	tests := []struct {
+		name         string
+		category     string
+		target       string
+		wantCompany  string
+		wantCategory string
+		wantFile     string
+		wantFound    bool
+	}{
+		{"selected category first", "meetings", "Jane Doe", "Clerky", "meetings", "jane doe.md", true},
+		{"rest of the company", "tasks", "Jane Doe", "Clerky", "people", "Jane Doe.md", true},
+		{"with extension", "people", "jane doe.md", "Clerky", "people", "Jane Doe.md", true},
+		{"other company", "people", "John Roe", "Qvest.US", "people", "John Roe.md", true},
+		{"missing", "people", "Nobody", "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			company, category, filename, found := resolveNoteLink(root, []Company{clerky, qvest}, clerky, tt.category, tt.target, ".md")
+
+			if found != tt.wantFound || company.DisplayName != tt.wantCompany || category != tt.wantCategory || filename != tt.wantFile {
+				t.Errorf("expected %s/%s/%s %v, got %s/%s/%s %v", tt.wantCompany, tt.wantCategory, tt.wantFile, tt.wantFound,
+					company.DisplayName, category, filename, found)
+			}
+		})
+	}
+}
+
+func TestLinkCursorWrapsAndResetsOnFileChange(t *testing.T) {
+	m := Model{
+		FileManager: FileManager{ContentCache: NewLRU[string, fileContent](10)},
+		ViewManager: ViewManager{CurrentView: DetailsView, ItemDetailsFocus: true},
+	}
+	note := FileInfo{Name: "a.md", FullPath: "/notes/a.md"}
+	m.FileManager.StoreContent(note, "[[One]] and [[Two]]")
+	m.FileManager.SelectedFile = note
+
+	m.GoToPreviousLink()
+	if link, ok := m.SelectedLink(); !ok || link.Target != "Two" {
+		t.Fatalf("expected shift+tab to select the last link, got %+v", link)
+	}
+
+	m.GoToNextLink()
+	if link, _ := m.SelectedLink(); link.Target != "One" {
+		t.Errorf("expected tab to wrap to the first link, got %+v", link)
+	}
+
+	if got := highlightLink("[[One]] and [[Two]]", NoteLink{Label: "One", Start: 0, End: 7}); got != "`▸ One` and [[Two]]" {
+		t.Errorf("unexpected highlight %q", got)
+	}
+
+	m.FileManager.SelectedFile = FileInfo{Name: "b.md", FullPath: "/notes/b.md"}
+	if _, ok := m.SelectedLink(); ok {
+		t.Errorf("expected the selection to belong to the previous note")
+	}
+}
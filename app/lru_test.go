@@ -0,0 +1,66 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewLRU[string, int](2)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatalf("expected a to be cached")
+	}
+
+	cache.Put("c", 3)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+
+	if value, ok := cache.Get("a"); !ok || value != 1 {
+		t.Errorf("expected a to survive, got %d %v", value, ok)
+	}
+
+	cache.Put("a", 10)
+	if value, _ := cache.Get("a"); value != 10 || cache.Len() != 2 {
+		t.Errorf("expected a to be updated in place, got %d with %d entries", value, cache.Len())
+	}
+
+	var disabled *LRU[string, int]
+	disabled.Put("a", 1)
+	if _, ok := disabled.Get("a"); ok {
+		t.Errorf("expected a nil cache to cache nothing")
+	}
+}
+
+func TestFileContentIsInvalidatedByChanges(t *testing.T) {
+	fm := FileManager{ContentCache: NewLRU[string, fileContent](10)}
+	file := FileInfo{Name: "a.md", FullPath: "/notes/a.md", UpdatedAt: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)}
+
+	if !fm.requestContent(file) {
+		t.Fatalf("expected uncached content to be requested")
+	}
+
+	if fm.requestContent(file) {
+		t.Errorf("expected a pending request not to be repeated")
+	}
+
+	fm.StoreContent(file, "# A")
+
+	if content, ok := fm.FileContent(file); !ok || content != "# A" {
+		t.Errorf("expected cached content, got %q %v", content, ok)
+	}
+
+	file.UpdatedAt = file.UpdatedAt.Add(time.Minute)
+
+	if _, ok := fm.FileContent(file); ok {
+		t.Errorf("expected content of a changed file to be reloaded")
+	}
+
+	if !fm.requestContent(file) {
+		t.Errorf("expected the changed file to be requested again")
+	}
+}
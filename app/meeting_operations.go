@@ -0,0 +1,149 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MeetingOperations handles creating meeting notes and extracting their
+// action items
+type MeetingOperations struct{}
+
+// NewMeeting opens the new meeting dialog. Typing [[ suggests attendees.
+func (mo MeetingOperations) NewMeeting(m *Model) tea.Cmd {
+	if m.IsCompanyView() {
+		return nil
+	}
+
+	m.ViewManager.IsAddMeetingView = true
+	m.Calendar.EventCursor = -1
+	m.Calendar.Prefilled = nil
+	m.NewTaskInput.Reset()
+	m.NewTaskInput.Prompt = "New " + m.GetCurrentCompanyName() + " meeting\n"
+	m.NewTaskInput.Placeholder = "Title, [[ to add attendees..."
+	if len(m.Calendar.EventsOn(m.GetCurrentCompanyName(), time.Now())) > 0 {
+		m.NewTaskInput.Placeholder = "Title, [[ to add attendees, tab to fill from today's events..."
+	}
+	m.NewTaskInput.Focus()
+	return nil
+}
+
+// NextEvent fills the new meeting input from the next of today's calendar
+// events. The meeting note then includes the event's details.
+func (mo MeetingOperations) NextEvent(m *Model) tea.Cmd {
+	events := m.Calendar.EventsOn(m.GetCurrentCompanyName(), time.Now())
+	if len(events) == 0 {
+		return nil
+	}
+
+	var people []string
+	for _, filename := range m.FileManager.PeopleFilenames(&m.DirectoryManager, &m.TaskManager, "") {
+		people = append(people, strings.TrimSuffix(filename, m.FileManager.FileExtension))
+	}
+
+	m.Calendar.EventCursor = (m.Calendar.EventCursor + 1) % len(events)
+	event := events[m.Calendar.EventCursor]
+	m.Calendar.Prefilled = &event
+
+	m.NewTaskInput.SetValue(eventMeetingInput(event, people))
+	m.NewTaskInput.CursorEnd()
+	return nil
+}
+
+// ExtractActionItems asks for the task file to move the open action items of
+// the selected meeting to
+func (mo MeetingOperations) ExtractActionItems(m *Model) tea.Cmd {
+	if !m.IsDetailsView() || m.DirectoryManager.SelectedCategory != "meetings" || m.FileManager.SelectedFile.Name == "" {
+		return nil
+	}
+
+	content, ok := m.FileManager.FileContent(m.FileManager.SelectedFile)
+	if !ok {
+		return nil
+	}
+
+	count := len(meetingActionItems(content))
+	if count == 0 {
+		m.Errors = append(m.Errors, "No open action items in "+m.FileManager.SelectedFile.FileNameWithoutExtension(m.FileManager.FileExtension))
+		return nil
+	}
+
+	m.ViewManager.IsExtractActionItemsView = true
+	m.NewTaskInput.Reset()
+	m.NewTaskInput.Prompt = fmt.Sprintf("Move %d action items to\n", count)
+	m.NewTaskInput.Placeholder = "[[ to pick a task file..."
+	m.NewTaskInput.Focus()
+	return nil
+}
+
+// SubmitMeeting creates the meeting note described by the input and opens it
+func (mo MeetingOperations) SubmitMeeting(m *Model) tea.Cmd {
+	meeting := parseMeetingInput(m.NewTaskInput.Value(), time.Now())
+	if m.Calendar.Prefilled != nil {
+		meeting.Details = eventDetails(*m.Calendar.Prefilled)
+		m.Calendar.Prefilled = nil
+	}
+
+	filename, err := m.FileManager.CreateMeeting(m.DirectoryManager.CurrentFolderPath(), meeting)
+	InputHandling{}.HandleEscape(m)
+
+	if err != nil {
+		m.Errors = append(m.Errors, err.Error())
+		return nil
+	}
+
+	m.OpenFile(m.DirectoryManager.SelectedCompany, "meetings", filename)
+	return nil
+}
+
+// SubmitActionItems moves the selected meeting's action items to the task
+// file named in the input
+func (mo MeetingOperations) SubmitActionItems(m *Model) tea.Cmd {
+	taskName := m.NewTaskInput.Value()
+	for _, link := range extractLinks(taskName) {
+		if link.IsNote() {
+			taskName = link.Target
+			break
+		}
+	}
+
+	_, err := m.FileManager.ExtractActionItems(m.DirectoryManager.CurrentFolderPath(), m.FileManager.SelectedFile, taskName)
+	if err != nil {
+		m.Errors = append(m.Errors, err.Error())
+	}
+
+	return InputHandling{}.HandleEscape(m)
+}
+
+// Command implementations for registry
+
+type UppercaseMKeyCommand struct{}
+
+func (cmd UppercaseMKeyCommand) Execute(m *Model) tea.Cmd {
+	return MeetingOperations{}.NewMeeting(m)
+}
+
+func (cmd UppercaseMKeyCommand) Description() string {
+	return "New meeting note"
+}
+
+func (cmd UppercaseMKeyCommand) Contexts() []string {
+	return []string{}
+}
+
+type UppercaseXKeyCommand struct{}
+
+func (cmd UppercaseXKeyCommand) Execute(m *Model) tea.Cmd {
+	return MeetingOperations{}.ExtractActionItems(m)
+}
+
+func (cmd UppercaseXKeyCommand) Description() string {
+	return "Extract meeting action items"
+}
+
+func (cmd UppercaseXKeyCommand) Contexts() []string {
+	return []string{"details"}
+}
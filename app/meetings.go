@@ -0,0 +1,230 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// defaultMeetingTemplate is used when the company has no meeting template.
+const defaultMeetingTemplate = `# {{title}}
+
+Date: {{date}}
+{{details}}
+## Attendees
+{{attendees}}
+
+## Agenda
+
+## Notes
+
+## Action items
+`
+
+// extractedMarker links an extracted action item to the task file it was
+// moved to. Marked items are not extracted again.
+const extractedMarker = "→ "
+
+var (
+	meetingDatePattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})\s+`)
+	actionItemPattern  = regexp.MustCompile(`^\s*- \[ \] (.*\S)\s*$`)
+)
+
+// MeetingNote is what the new meeting input describes: an optional leading
+// date, a title and [[attendees]]. Details come from the calendar event the
+// input was filled from.
+type MeetingNote struct {
+	Date      string
+	Title     string
+	Attendees []string
+	Details   string
+}
+
+// ActionItem is an unchecked item of a meeting note, by line of the file.
+type ActionItem struct {
+	Line int
+	Text string
+}
+
+// parseMeetingInput reads a meeting from input such as
+// "2024-03-05 Roadmap review [[Jane Doe]] [[John Roe]]". The date defaults
+// to today.
+func parseMeetingInput(input string, today time.Time) MeetingNote {
+	meeting := MeetingNote{Date: today.Format("2006-01-02")}
+	input = strings.TrimSpace(input)
+
+	if match := meetingDatePattern.FindStringSubmatch(input); match != nil {
+		meeting.Date = match[1]
+		input = input[len(match[0]):]
+	}
+
+	for _, link := range extractLinks(input) {
+		if link.IsNote() && !containsFold(meeting.Attendees, link.Target) {
+			meeting.Attendees = append(meeting.Attendees, link.Target)
+		}
+	}
+
+	title := wikilinkPattern.ReplaceAllString(input, "")
+	title = strings.ReplaceAll(strings.Join(strings.Fields(title), " "), "/", "-")
+	if title == "" {
+		title = "Meeting"
+	}
+	meeting.Title = title
+
+	return meeting
+}
+
+func (mn MeetingNote) Filename(extension string) string {
+	return mn.Date + " " + mn.Title + extension
+}
+
+// render fills in the template's {{date}}, {{title}}, {{attendees}} and
+// {{details}}.
+func (mn MeetingNote) render(template string) string {
+	var attendees []string
+	for _, attendee := range mn.Attendees {
+		attendees = append(attendees, "- [["+attendee+"]]")
+	}
+
+	details := mn.Details
+	if details != "" {
+		details += "\n"
+	}
+
+	return strings.NewReplacer(
+		"{{date}}", mn.Date,
+		"{{title}}", mn.Title,
+		"{{attendees}}", strings.Join(attendees, "\n"),
+		"{{details}}", details,
+	).Replace(template)
+}
+
+// CreateMeeting writes a meeting note from the company's meeting template
+// and returns its filename.
+func (fm FileManager) CreateMeeting(companyFolder string, meeting MeetingNote) (string, error) {
+	filename := meeting.Filename(fm.FileExtension)
+	filePath := filepath.Join(notesPath(), companyFolder, "meetings", filename)
+
+	if _, err := os.Stat(filePath); err == nil {
+		return "", fmt.Errorf("meeting note %s already exists", filename)
+	}
+
+	template := defaultMeetingTemplate
+	templatePath := filepath.Join(notesPath(), "obsidian", "templates", companyFolder+"_meeting.md")
+	if content, err := os.ReadFile(templatePath); err == nil {
+		template = string(content)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create meeting: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, []byte(meeting.render(template)), 0644); err != nil {
+		return "", fmt.Errorf("failed to create meeting: %w", err)
+	}
+
+	return filename, nil
+}
+
+// meetingActionItems lists the unchecked items of a meeting note that were
+// not extracted yet.
+func meetingActionItems(content string) []ActionItem {
+	var items []ActionItem
+
+	for index, line := range strings.Split(content, "\n") {
+		match := actionItemPattern.FindStringSubmatch(line)
+		if match == nil || strings.Contains(line, extractedMarker+"[[") {
+			continue
+		}
+
+		items = append(items, ActionItem{Line: index, Text: match[1]})
+	}
+
+	return items
+}
+
+// insertSubTasks adds tasks, in order, under the task file's sub-tasks
+// heading, adding the heading when the file has none.
+func insertSubTasks(content string, tasks []string) string {
+	lines := strings.Split(content, "\n")
+
+	heading := -1
+	for index, line := range lines {
+		if strings.Contains(line, "### Sub-tasks") {
+			heading = index
+			break
+		}
+	}
+
+	if heading == -1 {
+		lines = append(lines, "", "### Sub-tasks", "")
+		heading = len(lines) - 2
+	}
+
+	position := min(heading+2, len(lines))
+	var newLines []string
+	for _, task := range tasks {
+		newLines = append(newLines, "- [ ] "+task)
+	}
+
+	return strings.Join(append(lines[:position], append(newLines, lines[position:]...)...), "\n")
+}
+
+// ExtractActionItems moves the open action items of a meeting into a task
+// file as sub-tasks linking back to the meeting, and marks them in the
+// meeting. It returns how many items were extracted.
+func (fm FileManager) ExtractActionItems(companyFolder string, meeting FileInfo, taskName string) (int, error) {
+	taskName = strings.TrimSuffix(strings.TrimSpace(taskName), fm.FileExtension)
+	taskPath := filepath.Join(notesPath(), companyFolder, "tasks", taskName+fm.FileExtension)
+
+	taskContent, err := os.ReadFile(taskPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read task file %s: %w", taskName, err)
+	}
+
+	meetingContent, err := os.ReadFile(meeting.FullPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read meeting: %w", err)
+	}
+
+	items := meetingActionItems(string(meetingContent))
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	meetingName := strings.TrimSuffix(meeting.Name, fm.FileExtension)
+	meetingLines := strings.Split(string(meetingContent), "\n")
+
+	var subTasks []string
+	for _, item := range items {
+		subTasks = append(subTasks, item.Text+" [["+meetingName+"]]")
+		meetingLines[item.Line] = strings.TrimRight(meetingLines[item.Line], " ") + " " + extractedMarker + "[[" + taskName + "]]"
+	}
+
+	// Mark the items before adding them, so a failed mark never leaves
+	// sub-tasks that a retry adds again; a failed add unmarks them
+	if err := os.WriteFile(meeting.FullPath, []byte(strings.Join(meetingLines, "\n")), 0644); err != nil {
+		return 0, fmt.Errorf("failed to mark action items: %w", err)
+	}
+
+	if err := os.WriteFile(taskPath, []byte(insertSubTasks(string(taskContent), subTasks)), 0644); err != nil {
+		if restoreErr := os.WriteFile(meeting.FullPath, meetingContent, 0644); restoreErr != nil {
+			log.Warn("Failed to unmark action items", "meeting", meeting.Name, "error", restoreErr)
+		}
+		return 0, fmt.Errorf("failed to write action items: %w", err)
+	}
+
+	for _, task := range subTasks {
+		appendHistory(HistoryEntry{Company: companyFolder, File: taskName + fm.FileExtension, Task: task, To: "created"})
+
+		log.Info("Calling MindMapUpdater.AppendSubtask", "parentTaskID", taskName, "taskName", task)
+		fm.Updater.AppendSubtask(taskName, task, task)
+	}
+
+	return len(items), nil
+}
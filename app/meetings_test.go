@@ -0,0 +1,115 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+	"vision/mindmap"
+)
+
+func TestParseMeetingInput(t *testing.T) {
+	today := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input string
+		want  MeetingNote
+	}{
+		{"Roadmap review [[Jane Doe]] [[John Roe]]", MeetingNote{Date: "2024-03-04", Title: "Roadmap review", Attendees: []string{"Jane Doe", "John Roe"}}},
+		{"2024-03-05 1:1 with [[Jane Doe|Jane]] [[jane doe]]", MeetingNote{Date: "2024-03-05", Title: "1:1 with", Attendees: []string{"Jane Doe"}}},
+		{"Design/API sync", MeetingNote{Date: "2024-03-04", Title: "Design-API sync"}},
+		{"  ", MeetingNote{Date: "2024-03-04", Title: "Meeting"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := parseMeetingInput(tt.input, today); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCreateMeetingFromTemplate(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	writeTestNotes(t, filepath.Join(home, "Notes", "obsidian", "templates"), map[string]string{"clerky_meeting.md": "# {{title}} ({{date}})\n{{attendees}}\n"})
+
+	fm := FileManager{FileExtension: ".md"}
+	meeting := MeetingNote{Date: "2024-03-05", Title: "Planning", Attendees: []string{"Jane Doe"}}
+
+	filename, err := fm.CreateMeeting("clerky", meeting)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(home, "Notes", "clerky", "meetings", filename))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if filename != "2024-03-05 Planning.md" || string(content) != "# Planning (2024-03-05)\n- [[Jane Doe]]\n" {
+		t.Errorf("unexpected meeting %q: %q", filename, content)
+	}
+
+	if _, err := fm.CreateMeeting("clerky", meeting); err == nil {
+		t.Errorf("expected an existing meeting not to be overwritten")
+	}
+
+	if _, err := fm.CreateMeeting("qvest_us", meeting); err != nil {
+		t.Errorf("expected the default template without a company template, got %v", err)
+	}
+}
+
+func TestExtractActionItems(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	meetings := filepath.Join(home, "Notes", "clerky", "meetings")
+	tasks := filepath.Join(home, "Notes", "clerky", "tasks")
+	writeTestNotes(t, meetings, map[string]string{"2024-03-05 Planning.md": "## Action items\n- [ ] Send invoice\n- [x] Done already\n- [ ] Book room \n"})
+	writeTestNotes(t, tasks, map[string]string{"billing.md": "# Billing\n### Sub-tasks\n\n- [ ] Existing\n", "empty.md": "# Empty"})
+
+	fm := FileManager{FileExtension: ".md", Updater: mindmap.NewNullUpdater()}
+	meeting := FileInfo{Name: "2024-03-05 Planning.md", FullPath: filepath.Join(meetings, "2024-03-05 Planning.md")}
+
+	count, err := fm.ExtractActionItems("clerky", meeting, "billing")
+	if err != nil || count != 2 {
+		t.Fatalf("expected 2 extracted items, got %d %v", count, err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{filepath.Join(tasks, "billing.md"), "# Billing\n### Sub-tasks\n\n- [ ] Send invoice [[2024-03-05 Planning]]\n- [ ] Book room [[2024-03-05 Planning]]\n- [ ] Existing\n"},
+		{meeting.FullPath, "## Action items\n- [ ] Send invoice → [[billing]]\n- [x] Done already\n- [ ] Book room → [[billing]]\n"},
+	}
+
+	for _, tt := range tests {
+		if content, _ := os.ReadFile(tt.path); string(content) != tt.want {
+			t.Errorf("%s: expected %q, got %q", filepath.Base(tt.path), tt.want, content)
+		}
+	}
+
+	var history HistoryState
+	if err := history.Refresh(historyPath()); err != nil {
+		t.Fatal(err)
+	}
+	sendInvoice := Task{Company: "clerky", FileName: "billing.md", Text: " Send invoice [[2024-03-05 Planning]]"}
+	if created := entriesForTask(history.Entries, sendInvoice); len(history.Entries) != 2 || len(created) != 1 || created[0].To != "created" {
+		t.Errorf("expected a created entry for each item, got %+v", history.Entries)
+	}
+
+	if count, _ := fm.ExtractActionItems("clerky", meeting, "empty"); count != 0 {
+		t.Errorf("expected extracted items not to be extracted again, got %d", count)
+	}
+
+	if _, err := fm.ExtractActionItems("clerky", meeting, "missing"); err == nil {
+		t.Errorf("expected a missing task file to fail")
+	}
+
+	if got := insertSubTasks("# Empty", []string{"One"}); got != "# Empty\n\n### Sub-tasks\n\n- [ ] One" {
+		t.Errorf("expected a sub-tasks heading to be added, got %q", got)
+	}
+}
@@ -1,6 +1,11 @@
 package app
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"time"
+	"vision/ical"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 // messages.go defines custom Bubble Tea message types for state changes.
 // These messages decouple actions from state mutations, following the Elm Architecture.
@@ -53,9 +58,71 @@ type (
 		Err      error
 	}
 
-	// FilesRefreshedMsg indicates the file list was refreshed
+	// FilesRefreshedMsg carries the result of a background file load.
+	// Generation identifies the load so superseded results can be dropped.
 	FilesRefreshedMsg struct {
-		Files []FileInfo
+		Generation int
+		Company    Company
+		Category   string
+		Files      []FileInfo
+		Notes      []IndexedFile
+		TaskFiles  []IndexedFile
+		Events     []ical.Event
+		Err        error
+	}
+
+	// ReminderTickMsg triggers a check for due meeting reminders
+	ReminderTickMsg struct {
+		Time time.Time
+	}
+
+	// ReminderDueMsg carries the banner of a meeting about to start
+	ReminderDueMsg struct {
+		Banner ReminderBanner
+	}
+
+	// SyncTickMsg triggers a periodic CalDAV sync
+	SyncTickMsg struct {
+		Time time.Time
+	}
+
+	// CalDAVSyncedMsg carries the result of a CalDAV sync
+	CalDAVSyncedMsg struct {
+		Company string
+		Time    time.Time
+		Result  SyncResult
+		Records syncRecords
+		Err     error
+	}
+
+	// LinkGraphBuiltMsg carries the link graph of every company
+	LinkGraphBuiltMsg struct {
+		Graph *LinkGraph
+		Err   error
+	}
+
+	// CompanyTasksLoadedMsg carries every company's tasks for the company
+	// swimlanes of the kanban view
+	CompanyTasksLoadedMsg struct {
+		Collections map[string]TaskCollection
+	}
+
+	// DashboardLoadedMsg carries the company cards of the dashboard
+	DashboardLoadedMsg struct {
+		Cards []DashboardCard
+	}
+
+	// QuickPanelLoadedMsg carries the newest notes of the quick-access panel
+	QuickPanelLoadedMsg struct {
+		Company  string
+		Meetings []FileInfo
+		People   []FileInfo
+	}
+
+	// SearchCompletedMsg carries the results of a vault-wide search
+	SearchCompletedMsg struct {
+		Query   string
+		Results []SearchResult
 	}
 )
 
@@ -7,6 +7,7 @@ import (
 	"vision/config"
 	"vision/mindmap"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -22,6 +23,17 @@ type Model struct {
 	Viewport         viewport.Model
 	NewTaskInput     textinput.Model
 	FilterInput      textinput.Model
+	Spinner          spinner.Model
+	SearchInput      textinput.Model
+	Search           SearchState
+	Links            LinkState
+	Reminders        ReminderState
+	Calendar         CalendarState
+	Timeline         TimelineState
+	History          HistoryState
+	Dashboard        DashboardState
+	QuickPanel       QuickPanelState
+	Sync             SyncState
 	Errors           []string
 }
 
@@ -38,7 +50,9 @@ func InitialModel(cfg *config.Config, args []string) tea.Model {
 	textInput := textinput.New()
 	textInput.Placeholder = "Add a task..."
 	filterInput := textinput.New()
-	filterInput.Placeholder = "Filter... (/ to start)"
+	filterInput.Placeholder = "Filter... (/ to start, e.g. status:started tag:bug due:<7d)"
+	searchInput := textinput.New()
+	searchInput.Placeholder = "Search all notes..."
 
 	monday := time.Now().AddDate(0, 0, -int(time.Now().Weekday())+1).Format("2006-01-02")
 	friday := time.Now().AddDate(0, 0, 5-int(time.Now().Weekday())).Format("2006-01-02")
@@ -59,6 +73,7 @@ func InitialModel(cfg *config.Config, args []string) tea.Model {
 		DirectoryManager: DirectoryManager{
 			Companies:        companies,
 			Categories:       cfg.Categories,
+			SavedViews:       SavedViewsFromConfig(cfg.SavedViews),
 			SelectedCompany:  defaultCompany,
 			SelectedCategory: "tasks",
 			CompaniesCursor:  0,
@@ -77,11 +92,13 @@ func InitialModel(cfg *config.Config, args []string) tea.Model {
 		FileManager: FileManager{
 			FilesCursor:       0,
 			Files:             []FileInfo{},
-			FileCache:         make(map[string][]FileInfo),
-			TaskCache:         make(map[string]map[string][]Task),
+			FileCache:         NewLRU[string, []FileInfo](cfg.CacheSize),
+			TaskCache:         NewLRU[string, map[string][]Task](cfg.CacheSize),
+			ContentCache:      NewLRU[string, fileContent](cfg.CacheSize),
 			PeopleSuggestions: []string{},
 			TaskSuggestions:   []string{},
 			FileExtension:     cfg.PreferredFileExtension,
+			Index:             OpenFileIndex(fileIndexPath()),
 		},
 		MindMapUpdater: mindMapUpdater,
 		ViewManager: ViewManager{
@@ -101,6 +118,7 @@ func InitialModel(cfg *config.Config, args []string) tea.Model {
 			IsAddSubTaskView:         false,
 			IsWeeklyView:             false,
 			ShowCompanies:            false,
+			KanbanColumns:            KanbanColumnsFromConfig(cfg.KanbanColumns),
 			KanbanListCursor:         0,
 			KanbanTaskCursor:         0,
 			KanbanTasksCount:         0,
@@ -112,6 +130,10 @@ func InitialModel(cfg *config.Config, args []string) tea.Model {
 		Viewport:     viewport.Model{},
 		NewTaskInput: textInput,
 		FilterInput:  filterInput,
+		SearchInput:  searchInput,
+		Spinner:      spinner.New(spinner.WithSpinner(spinner.Dot)),
+		Links:        LinkState{Opener: cfg.LinkOpener},
+		Reminders:    ReminderState{Reminders: RemindersFromConfig(cfg.Reminders, companies)},
 	}
 
 	// Initialize today's mind-map if using real updater
@@ -123,11 +145,20 @@ func InitialModel(cfg *config.Config, args []string) tea.Model {
 	m.FileManager.Updater = mindMapUpdater
 
 	SetArgs(&m, args)
-	m.FetchFiles()
+	m.FileManager.RequestRefresh()
+
+	// Without a company to open, land on the dashboard of all of them
+	m.ViewManager.IsDashboardView = len(args) == 0
 
 	return &m
 }
 
+// RebuildFileIndex re-parses every note of the configured companies into the
+// on-disk index and returns the number of indexed files.
+func RebuildFileIndex(cfg *config.Config) (int, error) {
+	return OpenFileIndex(fileIndexPath()).Rebuild(notesPath(), CompaniesFromConfig(cfg.Companies), cfg.PreferredFileExtension)
+}
+
 func SetArgs(m *Model, args []string) {
 	if len(args) > 0 {
 		requestedCompany := args[0]
@@ -151,7 +182,29 @@ func SetArgs(m *Model, args []string) {
 }
 
 func (m *Model) Init() tea.Cmd {
-	return nil
+	var cmds []tea.Cmd
+
+	if m.FileManager.refreshRequested {
+		cmds = append(cmds, m.refreshFilesCmd())
+	}
+
+	if len(m.Reminders.Reminders) > 0 {
+		cmds = append(cmds, reminderTickCmd())
+	}
+
+	if cmd := m.syncCmd(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+
+	if hasCalDAV(m.DirectoryManager.Companies) {
+		cmds = append(cmds, syncTickCmd())
+	}
+
+	if m.ViewManager.IsDashboardView {
+		cmds = append(cmds, m.dashboardCmd())
+	}
+
+	return tea.Batch(cmds...)
 }
 
 func (m *Model) IsCompanyView() bool {
@@ -174,10 +227,28 @@ func (m *Model) IsAddSubTaskView() bool {
 	return m.ViewManager.IsAddSubTaskView
 }
 
+func (m *Model) IsAddMeetingView() bool {
+	return m.ViewManager.IsAddMeetingView
+}
+
+func (m *Model) IsExtractActionItemsView() bool {
+	return m.ViewManager.IsExtractActionItemsView
+}
+
+// IsNewTaskInputView reports whether the task input is open, for a task, a
+// subtask, a meeting or the task file to extract action items to.
+func (m *Model) IsNewTaskInputView() bool {
+	return m.IsAddTaskView() || m.IsAddSubTaskView() || m.IsAddMeetingView() || m.IsExtractActionItemsView()
+}
+
 func (m *Model) IsFilterView() bool {
 	return m.ViewManager.IsFilterView
 }
 
+func (m *Model) IsSearchView() bool {
+	return m.ViewManager.IsSearchView
+}
+
 func (m *Model) IsTaskDetailsFocus() bool {
 	return m.ViewManager.IsTaskDetailsFocus()
 }
@@ -195,10 +266,12 @@ func (m *Model) IsSuggestionsActive() bool {
 }
 
 func (m *Model) GoToCompany(companyName string) {
+	m.ViewManager.IsDashboardView = false
 	m.DirectoryManager.SelectCompany(companyName)
-	m.FileManager.ResetCache()
 	m.TaskManager.TaskCollection.Flush()
-	m.FetchFiles()
+	m.FileManager.restoreCachedTasks(&m.DirectoryManager, &m.TaskManager)
+	m.FileManager.showCachedFiles(&m.DirectoryManager)
+	m.FileManager.RequestRefresh()
 }
 
 func (m *Model) GoToNextCompany() {
@@ -208,13 +281,14 @@ func (m *Model) GoToNextCompany() {
 		m.DirectoryManager.CompaniesCursor++
 	}
 	m.DirectoryManager.SelectedCompany = m.DirectoryManager.Companies[m.DirectoryManager.CompaniesCursor]
-	m.FileManager.ResetCache()
 	m.TaskManager.TaskCollection.Flush()
-	m.FetchFiles()
+	m.FileManager.restoreCachedTasks(&m.DirectoryManager, &m.TaskManager)
+	m.FileManager.showCachedFiles(&m.DirectoryManager)
+	m.FileManager.RequestRefresh()
 }
 
 func (m *Model) GoToNextCategory() {
-	goToNext(&m.DirectoryManager.CategoriesCursor, len(m.DirectoryManager.Categories))
+	goToNext(&m.DirectoryManager.CategoriesCursor, len(m.CategoryNames()))
 }
 
 func (m *Model) GoToNextTask() {
@@ -236,7 +310,7 @@ func (m *Model) GoToPreviousKanbanTask() {
 }
 
 func (m *Model) GoToNextKanbanList() {
-	goToNext(&m.ViewManager.KanbanListCursor, 3)
+	goToNext(&m.ViewManager.KanbanListCursor, len(m.ViewManager.Boards()))
 }
 
 func (m *Model) GoToPreviousKanbanList() {
@@ -286,11 +360,31 @@ func (m *Model) GoToNextViewWithCategory(category string) {
 	m.GoToNextView()
 }
 
+// GoToPreviousView goes back a view. Back on the categories, the query of a
+// saved view or of a folder-scoped filter no longer applies and is cleared.
 func (m *Model) GoToPreviousView() {
 	m.ViewManager.GoToPreviousView()
+
+	if m.IsCategoryView() && (m.DirectoryManager.SelectedSavedView != "" || m.FileManager.FileFilter != FileFilter{}) {
+		m.DirectoryManager.SelectedSavedView = ""
+		m.FileManager.FileFilter = FileFilter{}
+		m.FilterInput.Reset()
+		m.ApplyFilter("")
+	}
 }
 
+// Select opens the item under the cursor. A saved view loads its query into
+// the filter before its task files are fetched, so the filter can still be
+// refined from there.
 func (m *Model) Select() {
+	if m.IsCategoryView() && !m.ViewManager.HideSidebar {
+		if view, ok := m.DirectoryManager.SavedViewAtCursor(); ok {
+			m.FileManager.FileFilter = FileFilter{}
+			m.FilterInput.SetValue(view.Query)
+			m.ApplyFilter(view.Query)
+		}
+	}
+
 	m.ViewManager.Select(&m.FileManager, &m.DirectoryManager, &m.TaskManager)
 }
 
@@ -341,7 +435,63 @@ func (m *Model) CompanyNames() []string {
 }
 
 func (m *Model) CategoryNames() []string {
-	return m.DirectoryManager.Categories
+	return m.DirectoryManager.CategoryNames()
+}
+
+// ApplyFilter updates the task filter while the filter value is being typed.
+func (m *Model) ApplyFilter(value string) {
+	m.TaskManager.TaskCollection.FilterValue = filterQueryPart(value, &m.DirectoryManager)
+}
+
+// SubmitFilter applies a submitted filter value. A folder-scoped value such as
+// /clerky/meetings/roadmap switches to that company and folder and lists only
+// the notes whose content or tasks match.
+func (m *Model) SubmitFilter(value string) error {
+	scope, query, scoped, err := parseFilterScope(value, &m.DirectoryManager)
+	if err != nil {
+		return err
+	}
+
+	m.TaskManager.TaskCollection.FilterValue = query
+	m.TaskManager.TasksCursor = 0
+
+	if !scoped {
+		m.FileManager.FileFilter = FileFilter{}
+		return nil
+	}
+
+	if scope.Company.DisplayName != m.GetCurrentCompanyName() {
+		m.GoToCompany(strings.ToLower(scope.Company.DisplayName))
+	}
+
+	m.FileManager.FileFilter = FileFilter{
+		Company:  scope.Company.DisplayName,
+		Category: scope.Category,
+		Query:    query,
+	}
+	m.DirectoryManager.SelectCategory(strings.ToLower(scope.Category))
+	m.ViewManager.CurrentView = DetailsView
+	m.FileManager.FilesCursor = 0
+	m.FileManager.RequestRefresh()
+
+	return nil
+}
+
+// OpenFile shows a note in the details view, switching company and category
+// as needed. The note is selected once the category has loaded.
+func (m *Model) OpenFile(company Company, category string, filename string) {
+	if company.DisplayName != m.GetCurrentCompanyName() {
+		m.GoToCompany(strings.ToLower(company.DisplayName))
+	}
+
+	m.FileManager.FileFilter = FileFilter{}
+	m.DirectoryManager.SelectCategory(strings.ToLower(category))
+	m.ViewManager.CurrentView = DetailsView
+	m.FileManager.showCachedFiles(&m.DirectoryManager)
+	m.FileManager.FilesCursor = 0
+	m.FileManager.PendingSelection = filename
+	m.Viewport.GotoTop()
+	m.FileManager.RequestRefresh()
 }
 
 func (m *Model) FetchFiles() []FileInfo {
@@ -138,8 +138,18 @@ func (nc NavigationCommands) OpenGitHubDash(m *Model) tea.Cmd {
 	})
 }
 
-// NextSuggestion handles tab key - move to next suggestion
+// NextSuggestion handles tab key - move to next suggestion, to the next
+// link of the note being read, or fill a new meeting from the next event
 func (nc NavigationCommands) NextSuggestion(m *Model) tea.Cmd {
+	if m.IsLinkNavigation() {
+		m.GoToNextLink()
+		return nil
+	}
+
+	if m.IsAddMeetingView() && !m.IsSuggestionsActive() {
+		return MeetingOperations{}.NextEvent(m)
+	}
+
 	if m.ViewManager.SuggestionsListsCursor == -1 {
 		m.ViewManager.SuggestionsListsCursor = 0
 	}
@@ -147,8 +157,14 @@ func (nc NavigationCommands) NextSuggestion(m *Model) tea.Cmd {
 	return nil
 }
 
-// PreviousSuggestion handles shift+tab key - move to previous suggestion
+// PreviousSuggestion handles shift+tab key - move to previous suggestion, or
+// to the previous link of the note being read
 func (nc NavigationCommands) PreviousSuggestion(m *Model) tea.Cmd {
+	if m.IsLinkNavigation() {
+		m.GoToPreviousLink()
+		return nil
+	}
+
 	log.Info("PreviousSuggestion")
 	m.ViewManager.PreviousSuggestion(&m.FileManager)
 	return nil
@@ -251,7 +267,7 @@ func (cmd TabKeyCommand) Execute(m *Model) tea.Cmd {
 }
 
 func (cmd TabKeyCommand) Description() string {
-	return "Next suggestion"
+	return "Next suggestion or link"
 }
 
 func (cmd TabKeyCommand) Contexts() []string {
@@ -265,7 +281,7 @@ func (cmd ShiftTabKeyCommand) Execute(m *Model) tea.Cmd {
 }
 
 func (cmd ShiftTabKeyCommand) Description() string {
-	return "Previous suggestion"
+	return "Previous suggestion or link"
 }
 
 func (cmd ShiftTabKeyCommand) Contexts() []string {
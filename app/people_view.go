@@ -0,0 +1,122 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// recentMeetingsLimit caps the meetings listed for a person.
+const recentMeetingsLimit = 5
+
+// PersonContext is what the people view shows under a person's note to
+// prepare a 1:1.
+type PersonContext struct {
+	OpenTasks []Task
+	Waiting   []Task
+	Meetings  []Backlink
+}
+
+func (pc PersonContext) IsEmpty() bool {
+	return len(pc.OpenTasks) == 0 && len(pc.Waiting) == 0 && len(pc.Meetings) == 0
+}
+
+// mentionsPerson reports whether text links to the person's note.
+func mentionsPerson(text string, name string) bool {
+	for _, link := range extractLinks(text) {
+		if link.IsNote() && strings.EqualFold(link.Target, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildPersonContext collects the open tasks that mention a person, the
+// ones tagged #waiting on them, and the recent meetings linking to them.
+func buildPersonContext(person FileInfo, extension string, tc *TaskCollection, graph *LinkGraph) PersonContext {
+	var personContext PersonContext
+	name := strings.TrimSuffix(person.Name, extension)
+
+	for _, tasks := range tc.TasksByFile {
+		for _, task := range tasks {
+			if task.Completed || !mentionsPerson(task.Text, name) {
+				continue
+			}
+
+			if containsFold(task.Tags, "waiting") {
+				personContext.Waiting = append(personContext.Waiting, task)
+			} else {
+				personContext.OpenTasks = append(personContext.OpenTasks, task)
+			}
+		}
+	}
+
+	byFileAndLine := func(a, b Task) int {
+		if a.FileName != b.FileName {
+			return strings.Compare(a.FileName, b.FileName)
+		}
+		return a.LineNumber - b.LineNumber
+	}
+	slices.SortFunc(personContext.OpenTasks, byFileAndLine)
+	slices.SortFunc(personContext.Waiting, byFileAndLine)
+
+	for _, backlink := range graph.Backlinks(person, extension) {
+		if backlink.Category == "meetings" && len(personContext.Meetings) < recentMeetingsLimit {
+			personContext.Meetings = append(personContext.Meetings, backlink)
+		}
+	}
+
+	return personContext
+}
+
+// renderPersonContext renders a person's open items and meeting history.
+func renderPersonContext(personContext PersonContext, name string, extension string) string {
+	if personContext.IsEmpty() {
+		return ""
+	}
+
+	var sections []string
+
+	renderTasks := func(title string, tasks []Task) {
+		if len(tasks) == 0 {
+			return
+		}
+
+		items := []string{suggestionTitleStyle.Render(fmt.Sprintf("%s (%d)", title, len(tasks)))}
+		for _, task := range tasks {
+			file := strings.TrimSuffix(task.FileName, extension)
+			items = append(items, defaultTextStyle.Render("  "+strings.TrimSpace(task.Text))+searchSnippetStyle.Render(" · "+file))
+		}
+		sections = append(sections, joinVertical(items...))
+	}
+
+	renderTasks("Open tasks", personContext.OpenTasks)
+	renderTasks("Waiting on "+name, personContext.Waiting)
+
+	if len(personContext.Meetings) > 0 {
+		items := []string{suggestionTitleStyle.Render("Recent meetings")}
+		for _, meeting := range personContext.Meetings {
+			date := meeting.File.UpdatedAt.Format("Jan 2")
+			items = append(items, defaultTextStyle.Render("  "+meeting.File.FileNameWithoutExtension(extension))+searchSnippetStyle.Render(" · "+date))
+		}
+		sections = append(sections, joinVertical(items...))
+	}
+
+	return lipgloss.NewStyle().PaddingLeft(2).Render(strings.Join(sections, "\n\n"))
+}
+
+// personContextView renders the context of the selected person when the
+// people category is shown.
+func personContextView(m *Model) string {
+	if !strings.EqualFold(m.DirectoryManager.SelectedCategory, "people") || m.FileManager.SelectedFile.Name == "" {
+		return ""
+	}
+
+	person := m.FileManager.SelectedFile
+	extension := m.FileManager.FileExtension
+	personContext := buildPersonContext(person, extension, &m.TaskManager.TaskCollection, m.FileManager.LinkGraph)
+
+	return renderPersonContext(personContext, strings.TrimSuffix(person.Name, extension), extension)
+}
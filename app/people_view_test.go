@@ -0,0 +1,58 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildPersonContext(t *testing.T) {
+	jane := FileInfo{Name: "Jane Doe.md", FullPath: "/notes/clerky/people/Jane Doe.md"}
+	tc := TaskCollection{TasksByFile: map[string][]Task{
+		"billing.md": {
+			{Text: "Review invoices with [[Jane Doe]]", FileName: "billing.md", LineNumber: 3},
+			{Text: "Contract from [[jane doe|Jane]] #waiting", FileName: "billing.md", LineNumber: 1, Tags: []string{"waiting"}},
+			{Text: "Done with [[Jane Doe]]", FileName: "billing.md", LineNumber: 2, Completed: true},
+		},
+		"api.md": {
+			{Text: "Pair with [[Jane Doe]]", FileName: "api.md", LineNumber: 7},
+			{Text: "Ask [[John Roe]]", FileName: "api.md", LineNumber: 8},
+			{Text: "Mentions Jane Doe without a link", FileName: "api.md", LineNumber: 9},
+		},
+	}}
+
+	now := time.Now()
+	graph := NewLinkGraph()
+	for i := 0; i < recentMeetingsLimit+2; i++ {
+		meeting := FileInfo{Name: "sync.md", FullPath: "/notes/clerky/meetings/" + string(rune('a'+i)) + ".md", UpdatedAt: now.Add(-time.Duration(i) * time.Hour)}
+		graph.Add(Company{DisplayName: "Clerky"}, "meetings", meeting, []string{"Jane Doe"}, ".md")
+	}
+	graph.Add(Company{DisplayName: "Clerky"}, "projects", FileInfo{Name: "api.md", FullPath: "/notes/clerky/projects/api.md"}, []string{"Jane Doe"}, ".md")
+
+	context := buildPersonContext(jane, ".md", &tc, graph)
+
+	tests := []struct {
+		name  string
+		tasks []Task
+		want  []string
+	}{
+		{"open tasks by file and line", context.OpenTasks, []string{"Pair with [[Jane Doe]]", "Review invoices with [[Jane Doe]]"}},
+		{"waiting on them", context.Waiting, []string{"Contract from [[jane doe|Jane]] #waiting"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.tasks) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, tt.tasks)
+			}
+			for i, task := range tt.tasks {
+				if task.Text != tt.want[i] {
+					t.Errorf("task %d: expected %q, got %q", i, tt.want[i], task.Text)
+				}
+			}
+		})
+	}
+
+	if len(context.Meetings) != recentMeetingsLimit || context.Meetings[0].File.FullPath != "/notes/clerky/meetings/a.md" {
+		t.Errorf("expected the %d most recent meetings, got %+v", recentMeetingsLimit, context.Meetings)
+	}
+}
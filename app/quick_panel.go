@@ -0,0 +1,209 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// quickTaskLimit caps the recent task files and upcoming tasks listed.
+	quickTaskLimit = 5
+	// quickNoteLimit caps the meeting and people notes listed.
+	quickNoteLimit = 3
+)
+
+// quickLabels are the keys that open the panel's items, in order. Keys the
+// panel's navigation and quitting use are left out.
+const quickLabels = "1234567890abcdefgimnoprstuvwxyz"
+
+// QuickPanelState holds the newest meeting and people notes of the company
+// the quick-access panel was opened for.
+type QuickPanelState struct {
+	Company  string
+	Meetings []FileInfo
+	People   []FileInfo
+	Loading  bool
+}
+
+// quickItem is an entry of the quick-access panel that opens a note.
+type quickItem struct {
+	Section  string
+	Category string
+	File     string
+	Text     string
+}
+
+// newestNotes returns the most recently modified notes of dir.
+func newestNotes(dir string, extension string, limit int) []FileInfo {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var notes []FileInfo
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), extension) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		notes = append(notes, FileInfo{Name: entry.Name(), UpdatedAt: info.ModTime(), FullPath: filepath.Join(dir, entry.Name())})
+	}
+
+	slices.SortFunc(notes, func(a, b FileInfo) int { return b.UpdatedAt.Compare(a.UpdatedAt) })
+	return notes[:min(len(notes), limit)]
+}
+
+// quickPanelCmd reads the company's newest meeting and people notes in the
+// background.
+func (m *Model) quickPanelCmd() tea.Cmd {
+	company := m.DirectoryManager.SelectedCompany
+	extension := m.FileManager.FileExtension
+	m.QuickPanel.Loading = true
+
+	return func() tea.Msg {
+		root := filepath.Join(notesPath(), company.FolderPathName)
+		return QuickPanelLoadedMsg{
+			Company:  company.DisplayName,
+			Meetings: newestNotes(filepath.Join(root, "meetings"), extension, quickNoteLimit),
+			People:   newestNotes(filepath.Join(root, "people"), extension, quickNoteLimit),
+		}
+	}
+}
+
+// recentTaskFiles returns the task files by their tasks' latest date,
+// newest first.
+func recentTaskFiles(tc *TaskCollection, limit int) []string {
+	var files []string
+	for file := range tc.TasksByFile {
+		if tc.LastUpdatedAt(file) != "" {
+			files = append(files, file)
+		}
+	}
+
+	slices.SortFunc(files, func(a, b string) int {
+		if c := strings.Compare(tc.LastUpdatedAt(b), tc.LastUpdatedAt(a)); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	return files[:min(len(files), limit)]
+}
+
+// upcomingTasks returns the open tasks scheduled from today on, soonest
+// first.
+func upcomingTasks(tasks []Task, today string, limit int) []Task {
+	var upcoming []Task
+	for _, task := range tasks {
+		if !task.Completed && task.ScheduledDate >= today {
+			upcoming = append(upcoming, task)
+		}
+	}
+
+	slices.SortStableFunc(upcoming, func(a, b Task) int { return strings.Compare(a.ScheduledDate, b.ScheduledDate) })
+	return upcoming[:min(len(upcoming), limit)]
+}
+
+// quickPanelItems lists the panel's entries in the order of their labels.
+func (m *Model) quickPanelItems() []quickItem {
+	extension := m.FileManager.FileExtension
+	tc := &m.TaskManager.TaskCollection
+	var items []quickItem
+
+	for _, file := range recentTaskFiles(tc, quickTaskLimit) {
+		items = append(items, quickItem{"Recent tasks", "tasks", file, strings.TrimSuffix(file, extension) + " · " + tc.LastUpdatedAt(file)})
+	}
+	for _, note := range m.QuickPanel.Meetings {
+		items = append(items, quickItem{"Meetings", "meetings", note.Name, strings.TrimSuffix(note.Name, extension)})
+	}
+	for _, note := range m.QuickPanel.People {
+		items = append(items, quickItem{"People", "people", note.Name, strings.TrimSuffix(note.Name, extension)})
+	}
+
+	today := time.Now().Format("2006-01-02")
+	for _, task := range upcomingTasks(tc.allTasks(), today, quickTaskLimit) {
+		day, _ := parseDay(task.ScheduledDate)
+		items = append(items, quickItem{"Upcoming", "tasks", task.FileName, day.Format("Mon Jan 2") + " · " + taskTitle(task)})
+	}
+
+	return items[:min(len(items), len(quickLabels))]
+}
+
+// QuickPanelControl handles the keys of the quick-access panel
+type QuickPanelControl struct{}
+
+// Handles reports whether the panel takes the key. It takes every key
+// while open, so the ones it doesn't use never reach the view behind it.
+func (qc QuickPanelControl) Handles(key string, m *Model) bool {
+	return m.ViewManager.IsQuickPanelView
+}
+
+// HandleKey opens the labelled item, or closes the panel on esc or P
+func (qc QuickPanelControl) HandleKey(key string, m *Model) tea.Cmd {
+	if key == "esc" || key == "P" {
+		m.ViewManager.IsQuickPanelView = false
+		return nil
+	}
+
+	items := m.quickPanelItems()
+	index := -1
+	if len(key) == 1 {
+		index = strings.Index(quickLabels, key)
+	}
+	if index < 0 || index >= len(items) {
+		return nil
+	}
+
+	item := items[index]
+	m.ViewManager.IsQuickPanelView = false
+	m.OpenFile(m.DirectoryManager.SelectedCompany, item.Category, item.File)
+	return nil
+}
+
+// ToggleQuickPanel opens the panel for the current company, or closes it
+func (qc QuickPanelControl) ToggleQuickPanel(m *Model) tea.Cmd {
+	m.ViewManager.IsQuickPanelView = !m.ViewManager.IsQuickPanelView
+	if !m.ViewManager.IsQuickPanelView {
+		return nil
+	}
+
+	m.ViewManager.IsDashboardView = false
+	m.QuickPanel.Meetings, m.QuickPanel.People = nil, nil
+	return m.quickPanelCmd()
+}
+
+// renderQuickPanel renders the panel's sections with each item's key.
+func renderQuickPanel(m *Model) string {
+	width := m.ViewManager.DetailsViewWidth
+	lines := []string{summaryTitleStyle(width).Render(m.GetCurrentCompanyName() + " · quick access"), ""}
+
+	section := ""
+	for index, item := range m.quickPanelItems() {
+		if item.Section != section {
+			if section != "" {
+				lines = append(lines, "")
+			}
+			section = item.Section
+			lines = append(lines, suggestionTitleStyle.Render(section))
+		}
+		lines = append(lines, highlightedTextStyle.Render(string(quickLabels[index]))+"  "+defaultTextStyle.Render(item.Text))
+	}
+
+	if section == "" {
+		lines = append(lines, loadingTextStyle.Render("Nothing recent"))
+	}
+	if m.QuickPanel.Loading {
+		lines = append(lines, "", loadingTextStyle.Render("Loading notes..."))
+	}
+
+	lines = append(lines, "", loadingTextStyle.Render("press a key to open · esc to close"))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
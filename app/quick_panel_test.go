@@ -0,0 +1,81 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewestNotes(t *testing.T) {
+	dir := t.TempDir()
+	writeTestNotes(t, dir, map[string]string{"a.md": "", "b.md": "", "c.md": "", "notes.txt": ""})
+
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.Local)
+	for i, name := range []string{"b.md", "c.md", "a.md"} {
+		stamp := base.Add(time.Duration(i) * time.Hour)
+		if err := os.Chtimes(filepath.Join(dir, name), stamp, stamp); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	notes := newestNotes(dir, ".md", 2)
+	if len(notes) != 2 || notes[0].Name != "a.md" || notes[1].Name != "c.md" {
+		t.Errorf("expected a.md and c.md, got %+v", notes)
+	}
+
+	if notes := newestNotes(filepath.Join(dir, "missing"), ".md", 2); notes != nil {
+		t.Errorf("expected no notes for a missing folder, got %+v", notes)
+	}
+}
+
+func TestQuickPanelItems(t *testing.T) {
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	nextWeek := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+
+	m := &Model{
+		DirectoryManager: DirectoryManager{SelectedCompany: Company{DisplayName: "Clerky"}, Categories: []string{"Tasks", "Meetings"}},
+		ViewManager:      ViewManager{CurrentView: CategoriesView, IsQuickPanelView: true},
+		FileManager:      FileManager{FileExtension: ".md", FileCache: NewLRU[string, []FileInfo](2), TaskCache: NewLRU[string, map[string][]Task](2)},
+		QuickPanel:       QuickPanelState{Meetings: []FileInfo{{Name: "2024-03-01 Planning.md"}}, People: []FileInfo{{Name: "Ada.md"}}},
+	}
+	m.TaskManager.TaskCollection = TaskCollection{TasksByFile: map[string][]Task{
+		"Release.md":   {{Text: " Ship it", FileName: "Release.md", ScheduledDate: nextWeek}},
+		"Migration.md": {{Text: " Move data", FileName: "Migration.md", ScheduledDate: tomorrow}},
+		"Old.md":       {{Text: " Done", FileName: "Old.md", CompletedDate: "2020-01-01", Completed: true}},
+		"Someday.md":   {{Text: " Maybe", FileName: "Someday.md"}},
+	}}
+
+	items := m.quickPanelItems()
+
+	var files []string
+	for _, item := range items {
+		files = append(files, item.Section+":"+item.File)
+	}
+	want := "Recent tasks:Release.md Recent tasks:Migration.md Recent tasks:Old.md Meetings:2024-03-01 Planning.md People:Ada.md Upcoming:Migration.md Upcoming:Release.md"
+	if got := strings.Join(files, " "); got != want {
+		t.Errorf("expected items\n%s\ngot\n%s", want, got)
+	}
+
+	// Keys the panel doesn't use never reach the view behind it
+	for _, key := range []string{"j", "H", "enter", "9"} {
+		if !(QuickPanelControl{}).Handles(key, m) {
+			t.Errorf("expected the open panel to take %q", key)
+		}
+		QuickPanelControl{}.HandleKey(key, m)
+	}
+	if !m.ViewManager.IsQuickPanelView || m.ViewManager.IsHistoryView || m.IsDetailsView() {
+		t.Fatal("expected unused keys to leave the panel open and the view behind it alone")
+	}
+
+	// The sixth item opens with the "6" key
+	if !(QuickPanelControl{}).Handles("6", m) {
+		t.Fatal("expected the panel to handle its labels")
+	}
+	QuickPanelControl{}.HandleKey("6", m)
+
+	if m.ViewManager.IsQuickPanelView || !m.IsDetailsView() || m.FileManager.PendingSelection != "Migration.md" || m.DirectoryManager.SelectedCategory != "tasks" {
+		t.Errorf("expected Migration.md to be opened, got %q in %q", m.FileManager.PendingSelection, m.DirectoryManager.SelectedCategory)
+	}
+}
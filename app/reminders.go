@@ -0,0 +1,312 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+	"vision/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+)
+
+const (
+	// reminderTickInterval is how often reminders are checked.
+	reminderTickInterval = 30 * time.Second
+	defaultReminderLead  = 5 * time.Minute
+)
+
+var reminderWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Reminder is a recurring meeting to be reminded of shortly before it starts.
+type Reminder struct {
+	Company  Company
+	Meeting  string
+	Hour     int
+	Minute   int
+	Weekdays []time.Weekday
+	Lead     time.Duration
+	Standup  bool
+	Notify   bool
+}
+
+// ReminderBanner is the reminder shown in the navbar until the meeting starts.
+// Body is the full text sent as desktop notification.
+type ReminderBanner struct {
+	Title string
+	Start time.Time
+	Items []string
+	Body  string
+}
+
+// ReminderState holds the configured reminders and the ones already fired,
+// keyed by meeting and start time.
+type ReminderState struct {
+	Reminders []Reminder
+	Banner    *ReminderBanner
+	fired     map[string]bool
+}
+
+// RemindersFromConfig reads the configured reminders, skipping the ones with
+// an unknown company, time or weekday.
+func RemindersFromConfig(reminders []config.Reminder, companies []Company) []Reminder {
+	var result []Reminder
+
+	for _, configured := range reminders {
+		company, found := companyNamed(companies, configured.Company)
+
+		start, err := time.Parse("15:04", configured.Time)
+		if !found || err != nil {
+			log.Warn("Skipping reminder", "meeting", configured.Meeting, "company", configured.Company, "time", configured.Time)
+			continue
+		}
+
+		reminder := Reminder{
+			Company: company,
+			Meeting: configured.Meeting,
+			Hour:    start.Hour(),
+			Minute:  start.Minute(),
+			Lead:    time.Duration(configured.LeadMinutes) * time.Minute,
+			Standup: configured.Standup,
+			Notify:  configured.Notify,
+		}
+
+		if reminder.Lead <= 0 {
+			reminder.Lead = defaultReminderLead
+		}
+
+		valid := true
+		for _, day := range configured.Weekdays {
+			weekday, ok := reminderWeekdays[strings.ToLower(day)[:min(3, len(day))]]
+			if !ok {
+				log.Warn("Skipping reminder with unknown weekday", "meeting", configured.Meeting, "weekday", day)
+				valid = false
+				break
+			}
+			reminder.Weekdays = append(reminder.Weekdays, weekday)
+		}
+
+		if valid {
+			result = append(result, reminder)
+		}
+	}
+
+	return result
+}
+
+// Start returns when the meeting takes place on now's day.
+func (r Reminder) Start(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), now.Day(), r.Hour, r.Minute, 0, 0, now.Location())
+}
+
+// IsDue reports whether now falls within the lead time before the meeting.
+// Reminders without weekdays are due every day.
+func (r Reminder) IsDue(now time.Time) bool {
+	start := r.Start(now)
+
+	if len(r.Weekdays) > 0 && !slices.Contains(r.Weekdays, start.Weekday()) {
+		return false
+	}
+
+	return !now.Before(start.Add(-r.Lead)) && now.Before(start)
+}
+
+func (r Reminder) key(start time.Time) string {
+	return r.Company.FolderPathName + "/" + r.Meeting + "@" + start.Format(time.RFC3339)
+}
+
+// Due returns the reminders due at now that have not fired yet and marks
+// them as fired. A banner whose meeting started is cleared.
+func (rs *ReminderState) Due(now time.Time) []Reminder {
+	if rs.Banner != nil && !now.Before(rs.Banner.Start) {
+		rs.Banner = nil
+	}
+
+	if rs.fired == nil {
+		rs.fired = make(map[string]bool)
+	}
+
+	var due []Reminder
+	for _, reminder := range rs.Reminders {
+		key := reminder.key(reminder.Start(now))
+		if reminder.IsDue(now) && !rs.fired[key] {
+			rs.fired[key] = true
+			due = append(due, reminder)
+		}
+	}
+
+	return due
+}
+
+// agendaItems lists the open items under a meeting note's agenda heading.
+func agendaItems(content string) []string {
+	var items []string
+	inAgenda := false
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "#") {
+			inAgenda = strings.Contains(strings.ToLower(trimmed), "agenda")
+			continue
+		}
+
+		if !inAgenda {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "- [ ] "):
+			items = append(items, strings.TrimSpace(trimmed[len("- [ ] "):]))
+		case strings.HasPrefix(trimmed, "- [") || trimmed == "-" || trimmed == "- [ ]":
+			// Checked items and empty bullets are not open agenda items
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "):
+			items = append(items, strings.TrimSpace(trimmed[2:]))
+		}
+	}
+
+	return items
+}
+
+// latestMeetingNote finds the most recent meeting note of the company whose
+// name contains the meeting's name.
+func latestMeetingNote(root string, company Company, meeting string, extension string) (string, bool) {
+	entries, err := os.ReadDir(filepath.Join(root, company.FolderPathName, "meetings"))
+	if err != nil {
+		return "", false
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasSuffix(name, extension) && strings.Contains(strings.ToLower(name), strings.ToLower(meeting)) {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return "", false
+	}
+
+	slices.Sort(names)
+	return filepath.Join(root, company.FolderPathName, "meetings", names[len(names)-1]), true
+}
+
+// standupSummary generates the company's standup from its task files. The
+// items are today's tasks.
+func standupSummary(root string, company Company, today time.Time, extension string) (string, []string) {
+	tm := TaskManager{
+		TaskCollection:   TaskCollection{TasksByFile: make(map[string][]Task)},
+		DailySummaryDate: today.Format("2006-01-02"),
+		FileExtension:    extension,
+	}
+
+	for _, file := range readFileMetadataInDirectory(filepath.Join(root, company.FolderPathName, "tasks"), nil, extension) {
+		tm.TaskCollection.Add(file.File.Name, tm.CreateTasks(company, file.File.Name, file.Tasks))
+	}
+
+	var items []string
+	tasksByFile := tm.Summary(company.DisplayName)
+	for _, key := range sortTaskKeys(tasksByFile) {
+		for _, task := range tasksByFile[key] {
+			if item, ok := standupItem(task, tm.DailySummaryDate); ok {
+				items = append(items, item)
+			}
+		}
+	}
+
+	return tm.SummaryForSlack(company.DisplayName), items
+}
+
+// buildReminderBanner gathers what the reminder shows: the generated standup
+// for standups, the open agenda items of the latest meeting note otherwise.
+func buildReminderBanner(root string, reminder Reminder, now time.Time, extension string) ReminderBanner {
+	start := reminder.Start(now)
+	minutes := int(start.Sub(now).Round(time.Minute).Minutes())
+	banner := ReminderBanner{
+		Title: fmt.Sprintf("%s %s in %d min", reminder.Company.DisplayName, reminder.Meeting, minutes),
+		Start: start,
+	}
+
+	if reminder.Standup {
+		banner.Body, banner.Items = standupSummary(root, reminder.Company, now, extension)
+	} else if path, ok := latestMeetingNote(root, reminder.Company, reminder.Meeting, extension); ok {
+		if content, err := os.ReadFile(path); err == nil {
+			banner.Items = agendaItems(removeYAMLFrontmatter(string(content)))
+			banner.Body = strings.Join(banner.Items, "\n")
+		}
+	}
+
+	return banner
+}
+
+// notify shows a desktop notification without waiting for it.
+func notify(title string, body string) error {
+	var c *exec.Cmd
+	if runtime.GOOS == "darwin" {
+		script := fmt.Sprintf("display notification %q with title %q", body, title)
+		c = exec.Command("osascript", "-e", script)
+	} else {
+		c = exec.Command("notify-send", title, body)
+	}
+
+	if err := c.Start(); err != nil {
+		return err
+	}
+
+	go c.Wait()
+	return nil
+}
+
+// reminderTickCmd schedules the next reminder check
+func reminderTickCmd() tea.Cmd {
+	return tea.Tick(reminderTickInterval, func(t time.Time) tea.Msg {
+		return ReminderTickMsg{Time: t}
+	})
+}
+
+// reminderCmd builds a due reminder's banner off the update loop and sends
+// its desktop notification
+func (m *Model) reminderCmd(reminder Reminder, now time.Time) tea.Cmd {
+	extension := m.FileManager.FileExtension
+
+	return func() tea.Msg {
+		banner := buildReminderBanner(notesPath(), reminder, now, extension)
+
+		if reminder.Notify {
+			if err := notify(banner.Title, banner.Body); err != nil {
+				log.Warn("Failed to send reminder notification", "error", err)
+			}
+		}
+
+		return ReminderDueMsg{Banner: banner}
+	}
+}
+
+// renderReminderBanner renders the upcoming meeting and its first items on
+// one line each.
+func renderReminderBanner(m *Model) string {
+	banner := m.Reminders.Banner
+	if banner == nil {
+		return ""
+	}
+
+	view := reminderTitleStyle.Render("⏰ " + banner.Title)
+	if len(banner.Items) > 0 {
+		items := strings.Join(banner.Items, " · ")
+		if width := m.ViewManager.NavbarWidth - 4; width > 0 && len([]rune(items)) > width {
+			items = string([]rune(items)[:width-1]) + "…"
+		}
+		view = joinVertical(view, searchSnippetStyle.Render(items))
+	}
+
+	return view
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"vision/config"
+)
+
+func TestRemindersFromConfig(t *testing.T) {
+	clerky := Company{DisplayName: "Clerky", FolderPathName: "clerky"}
+	reminders := RemindersFromConfig([]config.Reminder{
+		{Company: "clerky", Meeting: "Standup", Time: "09:30", Weekdays: []string{"mon", "Friday"}, Standup: true},
+		{Company: "Clerky", Meeting: "Retro", Time: "16:00", LeadMinutes: 15},
+		{Company: "unknown", Meeting: "Sync", Time: "10:00"},
+		{Company: "clerky", Meeting: "Late", Time: "25:00"},
+		{Company: "clerky", Meeting: "Someday", Time: "10:00", Weekdays: []string{"funday"}},
+	}, []Company{clerky})
+
+	want := []Reminder{
+		{Company: clerky, Meeting: "Standup", Hour: 9, Minute: 30, Weekdays: []time.Weekday{time.Monday, time.Friday}, Lead: defaultReminderLead, Standup: true},
+		{Company: clerky, Meeting: "Retro", Hour: 16, Minute: 0, Lead: 15 * time.Minute},
+	}
+
+	if !reflect.DeepEqual(reminders, want) {
+		t.Errorf("expected %+v, got %+v", want, reminders)
+	}
+}
+
+func TestReminderStateFiresOncePerMeeting(t *testing.T) {
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.Local)
+	state := ReminderState{Reminders: []Reminder{
+		{Meeting: "Standup", Hour: 9, Minute: 30, Weekdays: []time.Weekday{time.Monday}, Lead: 5 * time.Minute},
+	}}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want int
+	}{
+		{"before the lead time", monday.Add(9*time.Hour + 24*time.Minute), 0},
+		{"within the lead time", monday.Add(9*time.Hour + 26*time.Minute), 1},
+		{"already fired", monday.Add(9*time.Hour + 28*time.Minute), 0},
+		{"meeting started", monday.Add(9*time.Hour + 30*time.Minute), 0},
+		{"other weekday", monday.AddDate(0, 0, 1).Add(9*time.Hour + 26*time.Minute), 0},
+		{"next week", monday.AddDate(0, 0, 7).Add(9*time.Hour + 26*time.Minute), 1},
+	}
+
+	for _, tt := range tests {
+		if got := state.Due(tt.now); len(got) != tt.want {
+			t.Errorf("%s: expected %d due reminders, got %d", tt.name, tt.want, len(got))
+		}
+	}
+}
+
+func TestBuildReminderBannerWithAgenda(t *testing.T) {
+	root := t.TempDir()
+	qvest := Company{DisplayName: "Qvest.US", FolderPathName: "qvest_us"}
+	writeTestNotes(t, filepath.Join(root, "qvest_us", "meetings"), map[string]string{
+		"2024-02-26 Sync.md":  "## Agenda\n- Old item\n",
+		"2024-03-04 Sync.md":  "---\ntitle: Sync\n---\n## Agenda\n- [ ] Hiring plan\n- [x] Budget\n- Roadmap\n-\n## Notes\n- Not agenda\n",
+		"2024-03-05 Retro.md": "## Agenda\n- Retro item\n",
+	})
+
+	now := time.Date(2024, 3, 7, 13, 52, 0, 0, time.Local)
+	banner := buildReminderBanner(root, Reminder{Company: qvest, Meeting: "Sync", Hour: 14}, now, ".md")
+
+	if banner.Title != "Qvest.US Sync in 8 min" || !banner.Start.Equal(now.Add(8*time.Minute)) {
+		t.Errorf("unexpected banner %q at %v", banner.Title, banner.Start)
+	}
+
+	if want := []string{"Hiring plan", "Roadmap"}; !reflect.DeepEqual(banner.Items, want) {
+		t.Errorf("expected agenda %v from the latest sync, got %v", want, banner.Items)
+	}
+}
+
+func TestBuildReminderBannerWithStandup(t *testing.T) {
+	root := t.TempDir()
+	clerky := Company{DisplayName: "Clerky", FolderPathName: "clerky"}
+	writeTestNotes(t, filepath.Join(root, "clerky", "tasks"), map[string]string{
+		"Billing.md": "- [x] Send invoice ⏳ 2024-03-07 ✅ 2024-03-07\n",
+		"Release.md": "- [ ] Ship it ⏳ 2024-03-07\n",
+	})
+
+	now := time.Date(2024, 3, 7, 9, 20, 0, 0, time.Local)
+	banner := buildReminderBanner(root, Reminder{Company: clerky, Meeting: "Standup", Hour: 9, Minute: 30, Standup: true}, now, ".md")
+
+	if want := []string{"Finished Send invoice", "Starting to work on Ship it"}; !reflect.DeepEqual(banner.Items, want) {
+		t.Errorf("expected today's standup items %v, got %v", want, banner.Items)
+	}
+	if !strings.Contains(banner.Body, "\nToday\n") {
+		t.Errorf("expected the generated standup as the body, got %q", banner.Body)
+	}
+}
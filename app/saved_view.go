@@ -0,0 +1,223 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"vision/config"
+)
+
+// savedViewPrefix marks saved views in the category sidebar so they stand
+// apart from the company's folders.
+const savedViewPrefix = "★ "
+
+// SavedView is a named filter listed after the category folders. Selecting it
+// lists the task files with tasks matching Query, sorted by SortBy (active,
+// updated, name, progress or scheduled) and grouped by GroupBy (status, tag
+// or none). Views without a Company are listed for every company.
+type SavedView struct {
+	Name    string `json:"name"`
+	Query   string `json:"query"`
+	Company string `json:"company"`
+	SortBy  string `json:"sortBy"`
+	GroupBy string `json:"groupBy"`
+}
+
+func CreateSavedViewFromConfigSavedView(view config.SavedView) SavedView {
+	return SavedView{
+		Name:    view.Name,
+		Query:   view.Query,
+		Company: view.Company,
+		SortBy:  view.SortBy,
+		GroupBy: view.GroupBy,
+	}
+}
+
+func SavedViewsFromConfig(views []config.SavedView) []SavedView {
+	var result []SavedView
+	for _, view := range views {
+		result = append(result, CreateSavedViewFromConfigSavedView(view))
+	}
+	return result
+}
+
+func (v SavedView) Label() string {
+	return savedViewPrefix + v.Name
+}
+
+// AppliesTo reports whether the view is listed for the company. The company
+// may be given by display name or folder name.
+func (v SavedView) AppliesTo(company Company) bool {
+	if v.Company == "" {
+		return true
+	}
+
+	name := strings.ToLower(v.Company)
+	return name == strings.ToLower(company.DisplayName) || name == strings.ToLower(company.FolderPathName)
+}
+
+// sortSavedViewFiles orders task files for a saved view. Progress and
+// scheduled dates are read from the filtered tasks, so they reflect the
+// view's query rather than the whole file.
+func sortSavedViewFiles(files []FileInfo, sortBy string, tm *TaskManager) []FileInfo {
+	sorted := slices.Clone(files)
+
+	switch sortBy {
+	case "updated":
+		slices.SortStableFunc(sorted, updatedAtCmp)
+	case "name":
+		slices.SortStableFunc(sorted, func(a, b FileInfo) int {
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+	case "progress":
+		slices.SortStableFunc(sorted, func(a, b FileInfo) int {
+			return compareFloats(fileProgress(b.Name, tm), fileProgress(a.Name, tm))
+		})
+	case "scheduled":
+		tasksByFile := tm.TaskCollection.GetTasksByFile()
+		slices.SortStableFunc(sorted, func(a, b FileInfo) int {
+			aDate := earliestScheduledDate(tasksByFile[a.Name])
+			bDate := earliestScheduledDate(tasksByFile[b.Name])
+
+			if aDate == bDate {
+				return 0
+			} else if aDate == "" {
+				return 1
+			} else if bDate == "" {
+				return -1
+			}
+			return strings.Compare(aDate, bDate)
+		})
+	default:
+		sorted = sortedFiles(sorted, tm)
+	}
+
+	return sorted
+}
+
+func fileProgress(filename string, tm *TaskManager) float64 {
+	completed, total := tm.TaskCollection.Progress(filename)
+	if total == 0 {
+		return 0
+	}
+	return float64(completed) / float64(total)
+}
+
+func compareFloats(a, b float64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+func earliestScheduledDate(tasks []Task) string {
+	earliest := ""
+	for _, task := range tasks {
+		if task.ScheduledDate != "" && (earliest == "" || task.ScheduledDate < earliest) {
+			earliest = task.ScheduledDate
+		}
+	}
+	return earliest
+}
+
+// taskFileGroup is a titled section of the task file list. Files holds
+// indexes into the listed files so the cursor keeps addressing FileManager.Files.
+type taskFileGroup struct {
+	Title string
+	Kind  string
+	Files []int
+}
+
+const (
+	activeFileGroup    = "active"
+	inactiveFileGroup  = "inactive"
+	completedFileGroup = "completed"
+)
+
+// groupTaskFiles splits task files into the sidebar sections. The status
+// grouping always returns the Active, Inactive and Complete sections, in
+// that order, even when some are empty.
+func groupTaskFiles(files []FileInfo, groupBy string, tc *TaskCollection) []taskFileGroup {
+	switch groupBy {
+	case "none":
+		group := taskFileGroup{}
+		for index := range files {
+			group.Files = append(group.Files, index)
+		}
+		return []taskFileGroup{group}
+	case "tag":
+		return groupTaskFilesByTag(files, tc)
+	}
+
+	groups := []taskFileGroup{
+		{Title: "Active", Kind: activeFileGroup},
+		{Title: "Inactive", Kind: inactiveFileGroup},
+		{Title: "Complete", Kind: completedFileGroup},
+	}
+
+	for index, file := range files {
+		switch taskFileStatus(file.Name, tc) {
+		case completedFileGroup:
+			groups[2].Files = append(groups[2].Files, index)
+		case inactiveFileGroup:
+			groups[1].Files = append(groups[1].Files, index)
+		default:
+			groups[0].Files = append(groups[0].Files, index)
+		}
+	}
+
+	return groups
+}
+
+// groupTaskFilesByTag groups files under the first tag of their filtered
+// tasks, falling back to the file's frontmatter tags.
+func groupTaskFilesByTag(files []FileInfo, tc *TaskCollection) []taskFileGroup {
+	var groups []taskFileGroup
+	positions := map[string]int{}
+	tasksByFile := tc.GetTasksByFile()
+
+	for index, file := range files {
+		title := "Untagged"
+
+		for _, task := range tasksByFile[file.Name] {
+			if len(task.Tags) > 0 {
+				title = "#" + task.Tags[0]
+				break
+			}
+		}
+
+		if title == "Untagged" && len(file.Tags) > 0 {
+			title = "#" + file.Tags[0]
+		}
+
+		position, ok := positions[title]
+		if !ok {
+			position = len(groups)
+			positions[title] = position
+			groups = append(groups, taskFileGroup{Title: title})
+		}
+
+		groups[position].Files = append(groups[position].Files, index)
+	}
+
+	return groups
+}
+
+func taskFileStatus(filename string, tc *TaskCollection) string {
+	completed, total := tc.Progress(filename)
+
+	if total > 0 && completed == total {
+		return completedFileGroup
+	} else if tc.IsInactive(filename) {
+		return inactiveFileGroup
+	}
+
+	return activeFileGroup
+}
+
+func taskFileProgressText(filename string, tc *TaskCollection) string {
+	completed, total := tc.Progress(filename)
+	return fmt.Sprintf("[%d/%d] ", completed, total)
+}
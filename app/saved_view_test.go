@@ -0,0 +1,100 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDirectoryManagerSavedViews(t *testing.T) {
+	clerky := Company{DisplayName: "Clerky", FolderPathName: "clerky"}
+	qvest := Company{DisplayName: "Qvest.US", FolderPathName: "qvest_us"}
+	dm := DirectoryManager{
+		Companies:  []Company{clerky, qvest},
+		Categories: []string{"tasks", "meetings"},
+		SavedViews: []SavedView{
+			{Name: "Waiting on others", Query: "tag:waiting"},
+			{Name: "Clerky priorities", Query: "status:priority", Company: "clerky"},
+		},
+		SelectedCompany: qvest,
+	}
+
+	expected := []string{"tasks", "meetings", "★ Waiting on others"}
+	if names := dm.CategoryNames(); !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+
+	dm.SelectedCompany = clerky
+	dm.CategoriesCursor = 3
+	dm.AssignCategory()
+
+	if dm.SelectedCategory != "tasks" || dm.SelectedSavedView != "Clerky priorities" {
+		t.Errorf("expected the Clerky priorities view over tasks, got %q over %q", dm.SelectedSavedView, dm.SelectedCategory)
+	}
+
+	if _, ok := dm.CurrentSavedView(); !ok {
+		t.Errorf("expected a current saved view")
+	}
+
+	dm.SelectedCompany = qvest
+	if _, ok := dm.CurrentSavedView(); ok {
+		t.Errorf("expected the Clerky view not to apply to Qvest.US")
+	}
+
+	dm.CategoriesCursor = 1
+	dm.AssignCategory()
+
+	if dm.SelectedCategory != "meetings" || dm.SelectedSavedView != "" {
+		t.Errorf("expected the meetings folder, got %q over %q", dm.SelectedSavedView, dm.SelectedCategory)
+	}
+}
+
+func TestSortSavedViewFiles(t *testing.T) {
+	tm := TaskManager{TaskCollection: TaskCollection{TasksByFile: map[string][]Task{
+		"a.md": {{Text: "a1", ScheduledDate: "2024-03-05"}, {Text: "a2", Completed: true}},
+		"b.md": {{Text: "b1", Completed: true}},
+		"c.md": {{Text: "c1", ScheduledDate: "2024-03-02"}, {Text: "c2"}},
+	}}}
+	files := []FileInfo{{Name: "c.md"}, {Name: "a.md"}, {Name: "b.md"}}
+
+	cases := []struct {
+		sortBy   string
+		expected []string
+	}{
+		{sortBy: "name", expected: []string{"a.md", "b.md", "c.md"}},
+		{sortBy: "progress", expected: []string{"b.md", "a.md", "c.md"}},
+		{sortBy: "scheduled", expected: []string{"c.md", "a.md", "b.md"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.sortBy, func(t *testing.T) {
+			var names []string
+			for _, file := range sortSavedViewFiles(files, c.sortBy, &tm) {
+				names = append(names, file.Name)
+			}
+
+			if !reflect.DeepEqual(names, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, names)
+			}
+		})
+	}
+}
+
+func TestGroupTaskFilesByTag(t *testing.T) {
+	tc := TaskCollection{TasksByFile: map[string][]Task{
+		"a.md": {{Text: "a1", Tags: []string{"bug"}}},
+		"b.md": {{Text: "b1"}},
+		"c.md": {{Text: "c1", Tags: []string{"bug", "review"}}},
+	}}
+	files := []FileInfo{{Name: "a.md"}, {Name: "b.md", Tags: []string{"client"}}, {Name: "c.md"}, {Name: "d.md"}}
+
+	groups := groupTaskFiles(files, "tag", &tc)
+	expected := []taskFileGroup{
+		{Title: "#bug", Files: []int{0, 2}},
+		{Title: "#client", Files: []int{1}},
+		{Title: "Untagged", Files: []int{3}},
+	}
+
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("expected %v, got %v", expected, groups)
+	}
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SearchControl handles the vault-wide search view
+type SearchControl struct{}
+
+// HandleKey routes keys pressed while browsing search results
+func (sc SearchControl) HandleKey(key string, m *Model) tea.Cmd {
+	switch key {
+	case "j", "down":
+		goToNext(&m.Search.Cursor, len(m.Search.Results))
+	case "k", "up":
+		goToPrevious(&m.Search.Cursor)
+	case "enter":
+		return sc.OpenResult(m)
+	case "e":
+		return sc.OpenResultInVim(m)
+	case "o":
+		return sc.OpenResultInObsidian(m)
+	case "/", "F":
+		m.SearchInput.Focus()
+	case "esc":
+		return sc.CloseSearch(m)
+	}
+	return nil
+}
+
+// StartSearch opens the search view with the search input focused
+func (sc SearchControl) StartSearch(m *Model) tea.Cmd {
+	m.ViewManager.IsSearchView = true
+	m.SearchInput.Focus()
+	return nil
+}
+
+// SubmitSearch runs the search for the current input off the UI thread
+func (sc SearchControl) SubmitSearch(m *Model) tea.Cmd {
+	query := strings.TrimSpace(m.SearchInput.Value())
+	m.SearchInput.Blur()
+
+	if query == "" {
+		m.Search = SearchState{}
+		return nil
+	}
+
+	m.Search = SearchState{Query: query, Searching: true}
+	return m.searchVaultCmd(query)
+}
+
+// CloseSearch leaves the search view, keeping the last results for the next search
+func (sc SearchControl) CloseSearch(m *Model) tea.Cmd {
+	m.ViewManager.IsSearchView = false
+	m.SearchInput.Blur()
+	return nil
+}
+
+// OpenResult shows the selected result's note in the details view
+func (sc SearchControl) OpenResult(m *Model) tea.Cmd {
+	result, ok := m.Search.SelectedResult()
+	if !ok {
+		return nil
+	}
+
+	m.OpenFile(result.Company, result.Category, result.Filename)
+	return sc.CloseSearch(m)
+}
+
+// OpenResultInVim opens the selected result in Vim at the matching line
+func (sc SearchControl) OpenResultInVim(m *Model) tea.Cmd {
+	if result, ok := m.Search.SelectedResult(); ok {
+		return openInVim(result.FullPath, result.Line)
+	}
+	return nil
+}
+
+// OpenResultInObsidian opens the selected result in Obsidian
+func (sc SearchControl) OpenResultInObsidian(m *Model) tea.Cmd {
+	if result, ok := m.Search.SelectedResult(); ok {
+		return openInObsidian(result.FullPath)
+	}
+	return nil
+}
+
+// Command implementations for registry
+
+type UppercaseFKeyCommand struct{}
+
+func (cmd UppercaseFKeyCommand) Execute(m *Model) tea.Cmd {
+	return SearchControl{}.StartSearch(m)
+}
+
+func (cmd UppercaseFKeyCommand) Description() string {
+	return "Search all notes"
+}
+
+func (cmd UppercaseFKeyCommand) Contexts() []string {
+	return []string{}
+}
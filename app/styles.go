@@ -19,8 +19,19 @@ var (
 	suggestionTextColor            = lipgloss.Color("#9A9CCD")
 	selectedSuggestionTextColor    = lipgloss.Color("#CB48B7")
 	priorityTextColor              = lipgloss.Color("#EC4E20")
+	tagChipTextColor               = lipgloss.Color("#1E1E1E")
 )
 
+var tagChipColors = []lipgloss.Color{
+	lipgloss.Color("#9A9CCD"),
+	lipgloss.Color("#F2D0A4"),
+	lipgloss.Color("#0AAFC7"),
+	lipgloss.Color("#4CD137"),
+	lipgloss.Color("#CB48B7"),
+	lipgloss.Color("#FF8A08"),
+	lipgloss.Color("#A9C23F"),
+}
+
 var (
 	defaultTextStyle            = lipgloss.NewStyle().Foreground(white)
 	companyTextStyle            = lipgloss.NewStyle().MarginLeft(2).MarginRight(2)
@@ -40,6 +51,11 @@ var (
 	suggestionTitleStyle        = lipgloss.NewStyle().Foreground(suggestionTitleColor).Bold(true)
 	suggestionTextStyle         = lipgloss.NewStyle().Foreground(suggestionTextColor)
 	selectedSuggestionTextStyle = lipgloss.NewStyle().Foreground(selectedSuggestionTextColor)
+	loadingTextStyle            = lipgloss.NewStyle().Foreground(inactiveFileColor)
+	reminderTitleStyle          = lipgloss.NewStyle().Foreground(scheduledColor).Bold(true)
+	eventTextStyle              = lipgloss.NewStyle().Foreground(startedColor)
+	searchSnippetStyle          = lipgloss.NewStyle().Foreground(inactiveFileColor)
+	searchMatchStyle            = lipgloss.NewStyle().Foreground(tagChipTextColor).Background(scheduledColor)
 )
 
 func navbarTextStyle(color string) lipgloss.Style {
@@ -194,6 +210,26 @@ func filterInputStyle(color string) lipgloss.Style {
 	return lipgloss.NewStyle().MarginLeft(1).Foreground(lipgloss.Color(color))
 }
 
+func tagChipStyle(color lipgloss.Color) lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(tagChipTextColor).Background(color).Padding(0, 1).MarginLeft(1)
+}
+
+func inactiveTagChipStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(inactiveFileColor).Padding(0, 1).MarginLeft(1)
+}
+
+func searchStatusStyle() lipgloss.Style {
+	return lipgloss.NewStyle().MarginLeft(1).MarginBottom(1).Foreground(suggestionTextColor)
+}
+
+func searchResultStyle() lipgloss.Style {
+	return lipgloss.NewStyle().MarginLeft(2).MarginBottom(1)
+}
+
+func filterErrorStyle() lipgloss.Style {
+	return lipgloss.NewStyle().MarginLeft(1).Foreground(overdueColor)
+}
+
 func taskOptionSuggestionsContainerStyle() lipgloss.Style {
 	return lipgloss.NewStyle().MarginLeft(2)
 }
@@ -0,0 +1,38 @@
+package app
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// SyncOperations handles syncing scheduled tasks with the company's CalDAV
+// calendar
+type SyncOperations struct{}
+
+// SyncTasks starts a sync, unless one is running or the company has no
+// CalDAV calendar
+func (so SyncOperations) SyncTasks(m *Model) tea.Cmd {
+	if m.Sync.Status == syncRunning {
+		return nil
+	}
+
+	if m.DirectoryManager.SelectedCompany.CalDAV.URL == "" {
+		m.Errors = append(m.Errors, "No CalDAV calendar configured for "+m.GetCurrentCompanyName())
+		return nil
+	}
+
+	return m.syncCmd()
+}
+
+// Command implementations for registry
+
+type UppercaseRKeyCommand struct{}
+
+func (cmd UppercaseRKeyCommand) Execute(m *Model) tea.Cmd {
+	return SyncOperations{}.SyncTasks(m)
+}
+
+func (cmd UppercaseRKeyCommand) Description() string {
+	return "Sync scheduled tasks with CalDAV"
+}
+
+func (cmd UppercaseRKeyCommand) Contexts() []string {
+	return []string{}
+}
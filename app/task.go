@@ -19,19 +19,24 @@ const (
 	completed_past
 )
 
+// Task is a checklist item of a task file. Company is the folder of the
+// company the file belongs to and CompanyName its display name.
 type Task struct {
 	Company       string
+	CompanyName   string
 	IsDone        bool
 	Text          string
 	StartDate     string
 	CompletedDate string
 	ScheduledDate string
+	DueDate       string
 	LineNumber    int
 	Completed     bool
 	Started       bool
 	Scheduled     bool
 	FileName      string
 	Priority      string
+	Tags          []string
 }
 
 const (
@@ -39,6 +44,7 @@ const (
 	CompletedIcon = "✅ "
 	ScheduledIcon = "⏳"
 	PriorityIcon  = "🔺 "
+	DueIcon       = "📅"
 )
 
 func (t Task) String() string {
@@ -265,6 +271,16 @@ func (t Task) LastUpdatedAt() string {
 	return ""
 }
 
+func (t Task) HasTag(tag string) bool {
+	for _, taskTag := range t.Tags {
+		if strings.EqualFold(taskTag, tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (t Task) textWithoutDates() string {
 	return removeDatesFromText(t.Text)
 }
@@ -277,6 +293,10 @@ func extractScheduledDateFromText(text string) string {
 	return extractDateFromText(text, ScheduledIcon)
 }
 
+func extractDueDateFromText(text string) string {
+	return extractDateFromText(text, DueIcon)
+}
+
 func extractCompletedDateFromText(text string) string {
 	return extractDateFromText(text, CompletedIcon)
 }
@@ -304,8 +324,41 @@ func extractDateFromText(text string, icon string) string {
 	return date
 }
 
+var tagRegex = regexp.MustCompile(`(^|\s)#([\p{L}\p{N}_/-]*[\p{L}_/-][\p{L}\p{N}_/-]*)`)
+
+// extractTagsFromText returns the #tags found in the text, without the leading
+// hash. Purely numeric tags such as issue references (#123) are ignored.
+func extractTagsFromText(text string) []string {
+	var tags []string
+
+	for _, match := range tagRegex.FindAllStringSubmatch(text, -1) {
+		tag := match[2]
+		if !containsFold(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+func removeTagsFromText(text string) string {
+	text = tagRegex.ReplaceAllString(text, "$1")
+
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func removeDatesFromText(text string) string {
-	datesRegex := regexp.MustCompile(`[✅, ⏳, 🛫]\s+\d{4}-\d{2}-\d{2}`)
+	datesRegex := regexp.MustCompile(`[✅, ⏳, 🛫, 📅]\s+\d{4}-\d{2}-\d{2}`)
 
 	text = datesRegex.ReplaceAllString(text, "")
 
@@ -1,14 +1,18 @@
 package app
 
 import (
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/log"
 )
 
 type TaskCollection struct {
 	TasksByFile map[string][]Task
+	FileTags    map[string][]string
 	FilterValue string
+	filterQuery *FilterQuery
 }
 
 func (tc *TaskCollection) GetTasksByFile() map[string][]Task {
@@ -16,18 +20,15 @@ func (tc *TaskCollection) GetTasksByFile() map[string][]Task {
 		return tc.TasksByFile
 	}
 
+	query := tc.FilterQuery()
+	today := time.Now().Format("2006-01-02")
 	filteredTasks := make(map[string][]Task)
 
 	for filename, tasks := range tc.TasksByFile {
 		var filtered []Task
 
-		if includesLowercase(filename, tc.FilterValue) {
-			filteredTasks[filename] = tasks
-			continue
-		}
-
 		for _, task := range tasks {
-			if includesLowercase(task.Text, tc.FilterValue) {
+			if query.Matches(task, filename, tc.FileTags[filename], today) {
 				filtered = append(filtered, task)
 			}
 		}
@@ -40,6 +41,32 @@ func (tc *TaskCollection) GetTasksByFile() map[string][]Task {
 	return filteredTasks
 }
 
+// FilterQuery returns the parsed FilterValue. The parse is cached until the
+// filter value changes. A value that fails to parse falls back to a plain
+// substring match so the list keeps narrowing while the query is being typed.
+func (tc *TaskCollection) FilterQuery() *FilterQuery {
+	if tc.filterQuery != nil && tc.filterQuery.source == tc.FilterValue {
+		return tc.filterQuery
+	}
+
+	query, err := ParseFilterQuery(tc.FilterValue)
+	if err != nil {
+		query.expr = textTerm{text: tc.FilterValue}
+	}
+
+	tc.filterQuery = query
+	return query
+}
+
+// FilterError returns the parse error of the current filter value, if any.
+func (tc *TaskCollection) FilterError() error {
+	if tc.FilterValue == "" {
+		return nil
+	}
+
+	return tc.FilterQuery().err
+}
+
 func includesLowercase(s, substr string) bool {
 	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
 }
@@ -52,6 +79,40 @@ func (tc *TaskCollection) Add(filename string, tasks []Task) {
 	}
 }
 
+func (tc *TaskCollection) AddFileTags(filename string, tags []string) {
+	if tc.FileTags == nil {
+		tc.FileTags = make(map[string][]string)
+	}
+
+	tc.FileTags[filename] = tags
+}
+
+// Tags returns every tag used by the tasks and files in the collection,
+// sorted alphabetically.
+func (tc *TaskCollection) Tags() []string {
+	var tags []string
+
+	for filename, tasks := range tc.TasksByFile {
+		for _, tag := range tc.FileTags[filename] {
+			if !containsFold(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+
+		for _, task := range tasks {
+			for _, tag := range task.Tags {
+				if !containsFold(tags, tag) {
+					tags = append(tags, tag)
+				}
+			}
+		}
+	}
+
+	sort.Strings(tags)
+
+	return tags
+}
+
 func (tc *TaskCollection) Size(filename string) int {
 	return len(tc.GetTasksByFile()[filename])
 }
@@ -267,6 +328,7 @@ func (tc *TaskCollection) GetAll() []Task {
 
 func (tc *TaskCollection) Flush() {
 	tc.TasksByFile = make(map[string][]Task)
+	tc.FileTags = make(map[string][]string)
 }
 
 func (tc *TaskCollection) IncompleteTasks(filename string, date string) []Task {
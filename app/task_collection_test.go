@@ -78,3 +78,49 @@ func TestFilteredForDay(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTasksByFileFiltersByTags(t *testing.T) {
+	tc := TaskCollection{
+		TasksByFile: map[string][]Task{
+			"login.md": {
+				{Text: "Fix redirect #bug", Tags: []string{"bug"}},
+				{Text: "Ask design for copy #waiting", Tags: []string{"waiting"}},
+			},
+			"billing.md": {
+				{Text: "Invoices are rounded #bug", Tags: []string{"bug"}},
+			},
+			"release.md": {
+				{Text: "Write changelog"},
+			},
+		},
+		FileTags: map[string][]string{
+			"release.md": {"review"},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		filter   string
+		expected map[string]int
+	}{
+		{name: "tag facet", filter: "#bug", expected: map[string]int{"login.md": 1, "billing.md": 1}},
+		{name: "tag facet with text", filter: "#bug redirect", expected: map[string]int{"login.md": 1}},
+		{name: "tag facet with filename", filter: "#bug billing", expected: map[string]int{"billing.md": 1}},
+		{name: "frontmatter tag", filter: "#review", expected: map[string]int{"release.md": 1}},
+		{name: "unknown tag", filter: "#missing", expected: map[string]int{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tc.FilterValue = c.filter
+			actual := map[string]int{}
+			for filename, tasks := range tc.GetTasksByFile() {
+				actual[filename] = len(tasks)
+			}
+
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+)
+
+// historyPaneLimit caps the entries the history pane lists.
+const historyPaneLimit = 10
+
+// HistoryEntry is a task mutation in the vault's history log. Company is
+// the folder of the task's company. To is "created" for new tasks and
+// subtasks; Date is the new ⏳ date of a reschedule.
+type HistoryEntry struct {
+	Time    time.Time `json:"time"`
+	Company string    `json:"company"`
+	File    string    `json:"file"`
+	Task    string    `json:"task"`
+	From    string    `json:"from,omitempty"`
+	To      string    `json:"to"`
+	Date    string    `json:"date,omitempty"`
+}
+
+// HistoryState holds the history log while the history pane is open, and
+// the size and time of the log it was read at.
+type HistoryState struct {
+	Entries []HistoryEntry
+	size    int64
+	modTime time.Time
+}
+
+func historyPath() string {
+	return filepath.Join(notesPath(), ".vision", "history.jsonl")
+}
+
+// appendHistory appends an entry to the history log. The markdown is the
+// source of truth, so a failed append is logged and the mutation stands.
+func appendHistory(entry HistoryEntry) {
+	if err := writeHistory(historyPath(), entry); err != nil {
+		log.Warn("Failed to record task history", "file", entry.File, "error", err)
+	}
+}
+
+func writeHistory(path string, entry HistoryEntry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// readHistory reads the history log in order. A missing log is empty;
+// unreadable lines are skipped.
+func readHistory(path string) ([]HistoryEntry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			log.Warn("Skipping unreadable history entry", "error", err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// taskStatusName names the status a task's dates give it.
+func taskStatusName(task Task) string {
+	switch {
+	case task.Completed:
+		return "completed"
+	case task.Started:
+		return "started"
+	case task.Scheduled:
+		return "scheduled"
+	}
+	return "unscheduled"
+}
+
+// taskChange records an update of the task to status.
+func taskChange(task Task, status string) HistoryEntry {
+	entry := HistoryEntry{
+		Company: task.Company,
+		File:    task.FileName,
+		Task:    strings.TrimSpace(task.Text),
+		From:    taskStatusName(task),
+		To:      status,
+	}
+	if status == "rescheduled" {
+		entry.Date = task.ScheduledDate
+	}
+	return entry
+}
+
+// entriesForTask returns the task's entries, newest first. Entries match on
+// the task's text without dates, which status changes rewrite.
+func entriesForTask(entries []HistoryEntry, task Task) []HistoryEntry {
+	var matching []HistoryEntry
+
+	for _, entry := range entries {
+		if entry.Company == task.Company && entry.File == task.FileName && taskTitle(Task{Text: entry.Task}) == taskTitle(task) {
+			matching = append(matching, entry)
+		}
+	}
+
+	slices.Reverse(matching)
+	return matching
+}
+
+// Refresh rereads the history log at path when it changed since it was read.
+func (hs *HistoryState) Refresh(path string) error {
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		*hs = HistoryState{}
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if info.Size() == hs.size && info.ModTime().Equal(hs.modTime) {
+		return nil
+	}
+
+	entries, err := readHistory(path)
+	if err != nil {
+		return err
+	}
+
+	*hs = HistoryState{Entries: entries, size: info.Size(), modTime: info.ModTime()}
+	return nil
+}
+
+// IsTaskSelectionView reports whether a task is selected: on the kanban
+// board or in a file's task list.
+func (m *Model) IsTaskSelectionView() bool {
+	return (m.IsCategoryView() && m.ViewManager.HideSidebar) || (m.IsDetailsView() && m.IsTaskDetailsFocus())
+}
+
+// refreshHistory rereads the history log while the history pane is open.
+func (m *Model) refreshHistory() {
+	if !m.ViewManager.IsHistoryView {
+		return
+	}
+
+	if err := m.History.Refresh(historyPath()); err != nil {
+		log.Warn("Failed to read task history", "error", err)
+	}
+}
+
+// renderTaskHistory renders the history pane of the selected task.
+func renderTaskHistory(m *Model) string {
+	if !m.ViewManager.IsHistoryView || !m.IsTaskSelectionView() {
+		return ""
+	}
+
+	task := m.TaskManager.SelectedTask
+	width := m.ViewManager.DetailsViewWidth
+	if task.Text == "" {
+		return loadingTextStyle.Render("Select a task to see its history")
+	}
+
+	title := summaryTitleStyle(width).Render("History · " + truncateString(taskTitle(task), 60))
+	entries := entriesForTask(m.History.Entries, task)
+	if len(entries) == 0 {
+		return joinVertical(title, loadingTextStyle.Render("No recorded changes"))
+	}
+
+	lines := []string{title}
+	for index, entry := range entries {
+		if index == historyPaneLimit {
+			lines = append(lines, loadingTextStyle.Render(fmt.Sprintf("and %d earlier", len(entries)-index)))
+			break
+		}
+		lines = append(lines, renderHistoryEntry(entry))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func renderHistoryEntry(entry HistoryEntry) string {
+	change := entry.To
+	if entry.From != "" {
+		change = entry.From + " → " + entry.To
+	}
+	if entry.Date != "" {
+		change += " " + entry.Date
+	}
+
+	return taskDateStyle().Render(entry.Time.Local().Format("2006-01-02 15:04")) + "  " + defaultTextStyle.Render(change)
+}
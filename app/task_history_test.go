@@ -0,0 +1,147 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"vision/mindmap"
+	"vision/utils"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTaskHistory(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	taskDir := filepath.Join(home, "Notes", "clerky", "tasks")
+	writeTestNotes(t, taskDir, map[string]string{
+		"Release.md": "# Release\n### Sub-tasks\n\n- [ ] Ship it ⏳ 2024-03-05\n",
+	})
+
+	var tm TaskManager
+	fm := &FileManager{FileExtension: ".md", Updater: mindmap.NewNullUpdater()}
+
+	task := func(text string) Task {
+		t.Helper()
+		content, _ := os.ReadFile(filepath.Join(taskDir, "Release.md"))
+		for _, task := range tm.CreateTasks(Company{DisplayName: "Clerky", FolderPathName: "clerky"}, "Release.md", utils.ExtractTasksFromText(string(content))) {
+			if taskTitle(task) == text {
+				return task
+			}
+		}
+		t.Fatalf("no task %q", text)
+		return Task{}
+	}
+
+	if err := tm.UpdateTaskToStarted(fm, task("Ship it")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.UpdateTaskToRescheduled(fm, task("Ship it"), "2024-03-08"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fm.CreateSubTask(Company{DisplayName: "Clerky", FolderPathName: "clerky"}, FileInfo{Name: "Release.md"}, "Write docs"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tasks that are not in the file fail and leave no history
+	if err := tm.UpdateTaskToCompleted(fm, Task{Company: "clerky", FileName: "Release.md", Text: " Missing"}); err == nil {
+		t.Error("expected updating a missing task to fail")
+	}
+
+	var history HistoryState
+	if err := history.Refresh(historyPath()); err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Entries) != 3 {
+		t.Fatalf("expected 3 entries, got %+v", history.Entries)
+	}
+
+	entries := entriesForTask(history.Entries, task("Ship it"))
+	if len(entries) != 2 {
+		t.Fatalf("expected the 2 changes of Ship it, got %+v", entries)
+	}
+	if entries[0].From != "started" || entries[0].To != "rescheduled" || entries[0].Date != "2024-03-08" {
+		t.Errorf("expected the reschedule first, got %+v", entries[0])
+	}
+	if entries[1].From != "scheduled" || entries[1].To != "started" || entries[1].Company != "clerky" || entries[1].Task != "Ship it ⏳ 2024-03-05" {
+		t.Errorf("expected the start with the old task text, got %+v", entries[1])
+	}
+
+	if created := entriesForTask(history.Entries, task("Write docs")); len(created) != 1 || created[0].To != "created" {
+		t.Errorf("expected the subtask creation, got %+v", created)
+	}
+
+	if err := tm.UpdateTaskToCompleted(fm, task("Write docs")); err != nil {
+		t.Fatal(err)
+	}
+	if err := history.Refresh(historyPath()); err != nil || len(history.Entries) != 4 {
+		t.Errorf("expected the log to be reread after a change, got %d entries and %v", len(history.Entries), err)
+	}
+}
+
+func TestAddSubTaskRecordsTheCompanyFolder(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	writeTestNotes(t, filepath.Join(home, "Notes", "qvest_us", "tasks"), map[string]string{
+		"Ops.md": "# Ops\n### Sub-tasks\n\n",
+	})
+
+	m := &Model{
+		ViewManager:      ViewManager{IsAddSubTaskView: true},
+		DirectoryManager: DirectoryManager{SelectedCompany: Company{DisplayName: "Qvest.US", FolderPathName: "qvest_us"}},
+		FileManager:      FileManager{FileExtension: ".md", Updater: mindmap.NewNullUpdater(), SelectedFile: FileInfo{Name: "Ops.md"}},
+		NewTaskInput:     textinput.New(),
+	}
+	m.NewTaskInput.SetValue("Rotate keys")
+
+	InputHandling{}.HandleEnter(m)
+
+	var history HistoryState
+	if err := history.Refresh(historyPath()); err != nil {
+		t.Fatal(err)
+	}
+
+	task := Task{Company: "qvest_us", FileName: "Ops.md", Text: " Rotate keys"}
+	if entries := entriesForTask(history.Entries, task); len(entries) != 1 || entries[0].To != "created" {
+		t.Errorf("expected the subtask's creation under the company folder, got %+v", history.Entries)
+	}
+}
+
+func TestHistoryPaneRereadsTheLogAfterKeys(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	entry := HistoryEntry{Company: "clerky", File: "Release.md", Task: "Ship it", From: "scheduled", To: "started"}
+	if err := writeHistory(historyPath(), entry); err != nil {
+		t.Fatal(err)
+	}
+
+	task := Task{Company: "clerky", FileName: "Release.md", Text: " Ship it"}
+	m := &Model{
+		ViewManager: ViewManager{CurrentView: CategoriesView, HideSidebar: true},
+		TaskManager: TaskManager{SelectedTask: task},
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	if !m.ViewManager.IsHistoryView || len(m.History.Entries) != 1 {
+		t.Fatalf("expected H to open the pane with the log, got %+v", m.History.Entries)
+	}
+
+	entry.From, entry.To = "started", "completed"
+	if err := writeHistory(historyPath(), entry); err != nil {
+		t.Fatal(err)
+	}
+
+	renderTaskHistory(m)
+	if len(m.History.Entries) != 1 {
+		t.Errorf("expected rendering to leave the log alone, got %+v", m.History.Entries)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if entries := entriesForTask(m.History.Entries, task); len(entries) != 2 || entries[0].To != "completed" {
+		t.Errorf("expected the next key to reread the log, got %+v", m.History.Entries)
+	}
+}
@@ -16,6 +16,10 @@ type TaskManager struct {
 	DailySummaryDate       string
 	SelectedTask           Task
 	FileExtension          string
+
+	// CompanyCollections holds every company's tasks, by display name, while
+	// the kanban view is grouped by company
+	CompanyCollections map[string]TaskCollection
 }
 
 type TaskCollectionSummary struct {
@@ -35,12 +39,17 @@ type TaskCollectionWeeklySummary struct {
 }
 
 func (tm *TaskManager) ExtractTasks(company string, name string, content string) []Task {
-	var tasks []Task
+	return tm.CreateTasks(Company{DisplayName: company, FolderPathName: company}, name, utils.ExtractTasksFromText(content))
+}
 
-	fileTasks := utils.ExtractTasksFromText(content)
+// CreateTasks builds the tasks of a company's file from its already
+// extracted checklist items.
+func (tm *TaskManager) CreateTasks(company Company, name string, fileTasks []utils.FileTask) []Task {
+	var tasks []Task
 
 	for _, fileTask := range fileTasks {
-		task := createTaskFromFileTask(company, name, fileTask)
+		task := createTaskFromFileTask(company.FolderPathName, name, fileTask)
+		task.CompanyName = company.DisplayName
 		tasks = append(tasks, task)
 	}
 
@@ -73,12 +82,8 @@ func (tm *TaskManager) SummaryForSlack(companyName string) string {
 		slackMessage.WriteString("• " + taskTitle + "\n")
 
 		for _, task := range tasks {
-			if task.Completed {
-				slackMessage.WriteString("  • Finished " + task.textWithoutDates() + "\n")
-			} else if task.Started && !task.IsScheduledForDay(previousDayString) {
-				slackMessage.WriteString("  • Kept working on " + task.textWithoutDates() + "\n")
-			} else if task.Scheduled {
-				slackMessage.WriteString("  • Starting to work on " + task.textWithoutDates() + "\n")
+			if item, ok := standupItem(task, previousDayString); ok {
+				slackMessage.WriteString("  • " + item + "\n")
 			}
 		}
 	}
@@ -94,12 +99,8 @@ func (tm *TaskManager) SummaryForSlack(companyName string) string {
 		slackMessage.WriteString("• " + taskTitle + "\n")
 
 		for _, task := range tasks {
-			if task.Completed {
-				slackMessage.WriteString("  • Finished " + task.textWithoutDates() + "\n")
-			} else if task.Started && !task.IsScheduledForDay(tm.DailySummaryDate) {
-				slackMessage.WriteString("  • Kept working on " + task.textWithoutDates() + "\n")
-			} else if task.Scheduled {
-				slackMessage.WriteString("  • Starting to work on " + task.textWithoutDates() + "\n")
+			if item, ok := standupItem(task, tm.DailySummaryDate); ok {
+				slackMessage.WriteString("  • " + item + "\n")
 			}
 		}
 	}
@@ -107,6 +108,19 @@ func (tm *TaskManager) SummaryForSlack(companyName string) string {
 	return slackMessage.String()
 }
 
+// standupItem is what the standup of date says about a task of its summary.
+func standupItem(task Task, date string) (string, bool) {
+	switch {
+	case task.Completed:
+		return "Finished " + task.textWithoutDates(), true
+	case task.Started && !task.IsScheduledForDay(date):
+		return "Kept working on " + task.textWithoutDates(), true
+	case task.Scheduled:
+		return "Starting to work on " + task.textWithoutDates(), true
+	}
+	return "", false
+}
+
 func (tm *TaskManager) WeeklySummaryForSlack(companyName string) string {
 	slackMessage := strings.Builder{}
 	summary := tm.WeeklySummary(companyName, tm.WeeklySummaryStartDate, tm.WeeklySummaryEndDate)
@@ -240,6 +254,12 @@ func (tm *TaskManager) UpdateTaskToScheduled(fm *FileManager, task Task) error {
 	return fm.UpdateTask(task, "scheduled")
 }
 
+// UpdateTaskToRescheduled moves the task's ⏳ date to date.
+func (tm *TaskManager) UpdateTaskToRescheduled(fm *FileManager, task Task, date string) error {
+	task.ScheduledDate = date
+	return fm.UpdateTask(task, "rescheduled")
+}
+
 func (tm *TaskManager) UpdateTaskToStarted(fm *FileManager, task Task) error {
 	return fm.UpdateTask(task, "started")
 }
@@ -260,6 +280,7 @@ func createTaskFromFileTask(company string, name string, task utils.FileTask) Ta
 	completedDate := extractCompletedDateFromText(task.Text)
 	startDate := extractStartDateFromText(task.Text)
 	scheduledDate := extractScheduledDateFromText(task.Text)
+	dueDate := extractDueDateFromText(task.Text)
 	priority := extractPriorityFromText(task.Text)
 	completed := completedDate != ""
 	started := startDate != ""
@@ -270,6 +291,7 @@ func createTaskFromFileTask(company string, name string, task utils.FileTask) Ta
 		Text:          task.Text,
 		StartDate:     startDate,
 		ScheduledDate: scheduledDate,
+		DueDate:       dueDate,
 		CompletedDate: completedDate,
 		Priority:      priority,
 		LineNumber:    task.LineNumber,
@@ -278,6 +300,7 @@ func createTaskFromFileTask(company string, name string, task utils.FileTask) Ta
 		Scheduled:     scheduled,
 		FileName:      name,
 		Company:       company,
+		Tags:          extractTagsFromText(task.Text),
 	}
 }
 
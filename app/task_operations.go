@@ -28,6 +28,10 @@ func (to TaskOperations) HandleKey(key string, m *Model) tea.Cmd {
 		return to.AddTask(m)
 	case "A":
 		return to.AddSubTask(m)
+	case "<":
+		return to.MoveTask(m, -1)
+	case ">":
+		return to.MoveTask(m, 1)
 	}
 	return nil
 }
@@ -38,7 +42,7 @@ func (to TaskOperations) CompleteTask(m *Model) tea.Cmd {
 		if err := m.TaskManager.UpdateTaskToCompleted(&m.FileManager, m.TaskManager.SelectedTask); err != nil {
 			m.Errors = append(m.Errors, err.Error())
 		}
-		m.FileManager.FetchTasks(&m.DirectoryManager, &m.TaskManager)
+		m.FileManager.RequestRefresh()
 	}
 	return nil
 }
@@ -62,7 +66,7 @@ func (to TaskOperations) ScheduleOrStartTask(m *Model) tea.Cmd {
 			m.ViewManager.KanbanListCursor = 1
 			m.ViewManager.IsKanbanTaskUpdated = true
 		}
-		m.FileManager.FetchFiles(&m.DirectoryManager, &m.TaskManager)
+		m.FileManager.RequestRefresh()
 	}
 	return nil
 }
@@ -77,13 +81,13 @@ func (to TaskOperations) TogglePriority(m *Model) tea.Cmd {
 			if err := m.TaskManager.UpdateTaskToPriority(&m.FileManager, selectedTask); err != nil {
 				m.Errors = append(m.Errors, err.Error())
 			}
-			m.FileManager.FetchTasks(&m.DirectoryManager, &m.TaskManager)
+			m.FileManager.RequestRefresh()
 		} else {
 			log.Info("Removing priority marker from task")
 			if err := m.TaskManager.UpdateTaskToUnpriority(&m.FileManager, selectedTask); err != nil {
 				m.Errors = append(m.Errors, err.Error())
 			}
-			m.FileManager.FetchTasks(&m.DirectoryManager, &m.TaskManager)
+			m.FileManager.RequestRefresh()
 		}
 	}
 	return nil
@@ -95,7 +99,7 @@ func (to TaskOperations) StartTaskOrCopyStandup(m *Model) tea.Cmd {
 		if err := m.TaskManager.UpdateTaskToStarted(&m.FileManager, m.TaskManager.SelectedTask); err != nil {
 			m.Errors = append(m.Errors, err.Error())
 		}
-		m.FileManager.FetchTasks(&m.DirectoryManager, &m.TaskManager)
+		m.FileManager.RequestRefresh()
 		return nil
 	}
 
@@ -130,11 +134,44 @@ func (to TaskOperations) ToggleScheduledUnscheduled(m *Model) tea.Cmd {
 			m.ViewManager.IsKanbanTaskUpdated = true
 			log.Info("Updating task to unscheduled ", m.ViewManager.IsKanbanTaskUpdated)
 		}
-		m.FileManager.FetchTasks(&m.DirectoryManager, &m.TaskManager)
+		m.FileManager.RequestRefresh()
 	}
 	return nil
 }
 
+// MoveTask moves the selected kanban card to the next column to the left
+// (-1) or right (1) that has a status, updating the task to that status. The
+// cursor follows the card when the updated task is listed on that column
+func (to TaskOperations) MoveTask(m *Model, direction int) tea.Cmd {
+	if !m.IsCategoryView() || !m.ViewManager.HideSidebar || m.ViewManager.KanbanTasksCount == 0 {
+		return nil
+	}
+
+	columns := m.ViewManager.Boards()
+	target, ok := kanbanMoveTarget(columns, m.ViewManager.KanbanListCursor, direction)
+	if !ok {
+		return nil
+	}
+
+	moved, err := m.TaskManager.UpdateTaskToStatus(&m.FileManager, m.TaskManager.SelectedTask, columns[target].Status)
+	if err != nil {
+		m.Errors = append(m.Errors, err.Error())
+		return nil
+	}
+
+	fileTags := m.TaskManager.TaskCollection.FileTags[moved.FileName]
+	if kanbanColumnIndex(columns, moved, moved.FileName, fileTags, m.TaskManager.DailySummaryDate) == target {
+		m.ViewManager.KanbanListCursor = target
+		m.ViewManager.KanbanTaskCursor = 0
+	} else {
+		log.Info("Moved task is not listed on the target column", "task", taskTitle(moved), "column", columns[target].Title)
+	}
+
+	m.ViewManager.IsKanbanTaskUpdated = true
+	m.FileManager.RequestRefresh()
+	return nil
+}
+
 // AddTask opens the add task dialog
 func (to TaskOperations) AddTask(m *Model) tea.Cmd {
 	m.ViewManager.IsAddTaskView = true
@@ -260,3 +297,31 @@ func (cmd UppercaseAKeyCommand) Description() string {
 func (cmd UppercaseAKeyCommand) Contexts() []string {
 	return []string{}
 }
+
+type LessThanKeyCommand struct{}
+
+func (cmd LessThanKeyCommand) Execute(m *Model) tea.Cmd {
+	return TaskOperations{}.MoveTask(m, -1)
+}
+
+func (cmd LessThanKeyCommand) Description() string {
+	return "Move task to the previous kanban column"
+}
+
+func (cmd LessThanKeyCommand) Contexts() []string {
+	return []string{"kanban"}
+}
+
+type GreaterThanKeyCommand struct{}
+
+func (cmd GreaterThanKeyCommand) Execute(m *Model) tea.Cmd {
+	return TaskOperations{}.MoveTask(m, 1)
+}
+
+func (cmd GreaterThanKeyCommand) Description() string {
+	return "Move task to the next kanban column"
+}
+
+func (cmd GreaterThanKeyCommand) Contexts() []string {
+	return []string{"kanban"}
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"reflect"
 	"testing"
 	"time"
 )
@@ -130,3 +131,35 @@ func TestStatusAtDate(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractTagsFromText(t *testing.T) {
+	cases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{name: "no tags", text: "Write the report", expected: nil},
+		{name: "single tag", text: "Fix login #bug", expected: []string{"bug"}},
+		{name: "multiple tags", text: "#review Check PR #waiting ⏳ 2024-01-02", expected: []string{"review", "waiting"}},
+		{name: "nested tag", text: "Ship it #project/vision", expected: []string{"project/vision"}},
+		{name: "numeric references are not tags", text: "Close issue #123", expected: nil},
+		{name: "anchors inside words are not tags", text: "See notes.md#section", expected: nil},
+		{name: "duplicate tags are only returned once", text: "#bug and #Bug", expected: []string{"bug"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual := extractTagsFromText(c.text)
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRemoveTagsFromText(t *testing.T) {
+	actual := removeTagsFromText("Fix login #bug before #review")
+	if actual != "Fix login before" {
+		t.Errorf("expected tags to be removed, got %q", actual)
+	}
+}
@@ -2,6 +2,8 @@ package app
 
 import (
 	"fmt"
+	"hash/fnv"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
@@ -23,7 +25,7 @@ func (tv TaskView) RenderedText() string {
 	}
 
 	icon := tv.statusIcon(status)
-	text := tv.task.Summary()
+	text := removeTagsFromText(tv.task.Summary())
 	textStyle := tv.textStyle(status)
 
 	if priority != "" && status != completed {
@@ -31,11 +33,16 @@ func (tv TaskView) RenderedText() string {
 	}
 
 	statusText := tv.statusText(status)
+	renderedTags := renderTagChips(tv.task.Tags)
+
+	if renderedTags != "" && tv.width > 0 {
+		textStyle = textStyle.Width(max(tv.width-lipgloss.Width(renderedTags), 1))
+	}
 
 	renderedText := textStyle.Render(text)
 	renderedStatusText := renderedStatusTextStyle.Render(statusText)
 
-	return joinHorizontal(icon, renderedText, renderedStatusText)
+	return joinHorizontal(icon, renderedText, renderedTags, renderedStatusText)
 }
 
 func (tv TaskView) RenderedKanbanText() string {
@@ -100,6 +107,25 @@ func (tv TaskView) textStyle(status status) lipgloss.Style {
 	return textStyle.Width(tv.width)
 }
 
+func renderTagChips(tags []string) string {
+	var chips []string
+
+	for _, tag := range tags {
+		chips = append(chips, tagChipStyle(colorForTag(tag)).Render("#"+tag))
+	}
+
+	return joinHorizontal(chips...)
+}
+
+// colorForTag picks a stable color for a tag so the same tag always renders
+// with the same chip color.
+func colorForTag(tag string) lipgloss.Color {
+	hash := fnv.New32a()
+	hash.Write([]byte(strings.ToLower(tag)))
+
+	return tagChipColors[hash.Sum32()%uint32(len(tagChipColors))]
+}
+
 func (tv TaskView) daysAgoFromString(date string) string {
 	parsedDate, err := time.Parse("2006-01-02", date)
 	if err != nil {
@@ -40,7 +40,7 @@ func (m *Model) refreshTasksCmd() tea.Cmd {
 }
 
 // createTaskCmd creates a new task
-func (m *Model) createTaskCmd(company string, taskName string) tea.Cmd {
+func (m *Model) createTaskCmd(company Company, taskName string) tea.Cmd {
 	return func() tea.Msg {
 		err := m.FileManager.CreateTask(company, taskName)
 		return TaskCreatedMsg{
@@ -53,7 +53,7 @@ func (m *Model) createTaskCmd(company string, taskName string) tea.Cmd {
 // createSubTaskCmd creates a new subtask
 func (m *Model) createSubTaskCmd(parentFile FileInfo, subtaskName string) tea.Cmd {
 	return func() tea.Msg {
-		err := m.FileManager.CreateSubTask(m.GetCurrentCompanyName(), parentFile, subtaskName)
+		err := m.FileManager.CreateSubTask(m.DirectoryManager.SelectedCompany, parentFile, subtaskName)
 		return SubTaskCreatedMsg{
 			ParentTask: Task{}, // Empty task for now
 			SubTask:    subtaskName,
@@ -65,18 +65,32 @@ func (m *Model) createSubTaskCmd(parentFile FileInfo, subtaskName string) tea.Cm
 // File Operation Commands
 
 // loadFileCmd loads a file's content
-func (m *Model) loadFileCmd(filename string) tea.Cmd {
+func (m *Model) loadFileCmd(file FileInfo) tea.Cmd {
 	return func() tea.Msg {
-		// In the actual implementation, this would load file content
-		// For now, return a message indicating the file was selected
+		content, err := readFileContent(file)
 		return FileLoadedMsg{
-			File:    FileInfo{Name: filename},
-			Content: "",
-			Err:     nil,
+			File:    file,
+			Content: content,
+			Err:     err,
 		}
 	}
 }
 
+// loadSelectedFileCmd loads the content of the file under the cursor when the
+// details view shows it and it is not cached yet
+func (m *Model) loadSelectedFileCmd() tea.Cmd {
+	if !m.IsDetailsView() || m.FileManager.FilesCursor < 0 || m.FileManager.FilesCursor >= len(m.FileManager.Files) {
+		return nil
+	}
+
+	file := m.FileManager.Files[m.FileManager.FilesCursor]
+	if !m.FileManager.requestContent(file) {
+		return nil
+	}
+
+	return m.loadFileCmd(file)
+}
+
 // createStandupCmd creates a new standup file
 func (m *Model) createStandupCmd(company string) tea.Cmd {
 	return func() tea.Msg {
@@ -88,12 +102,34 @@ func (m *Model) createStandupCmd(company string) tea.Cmd {
 	}
 }
 
-// refreshFilesCmd refreshes the file list
+// refreshFilesCmd loads the selected category in the background, cancelling
+// any load still in flight, and starts the sidebar spinner. The link graph
+// is built along with the first load.
 func (m *Model) refreshFilesCmd() tea.Cmd {
+	load := m.FileManager.startLoad(&m.DirectoryManager)
+	cmds := []tea.Cmd{func() tea.Msg {
+		return loadFiles(load)
+	}, m.Spinner.Tick}
+
+	if !m.FileManager.linkGraphRequested {
+		m.FileManager.linkGraphRequested = true
+		cmds = append(cmds, func() tea.Msg {
+			return loadLinkGraph(load)
+		})
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// searchVaultCmd searches every company's notes for the query
+func (m *Model) searchVaultCmd(query string) tea.Cmd {
+	companies := m.DirectoryManager.Companies
+	extension := m.FileManager.FileExtension
+
 	return func() tea.Msg {
-		// Files are refreshed by FetchTasks or LoadFile operations
-		return FilesRefreshedMsg{
-			Files: m.FileManager.Files,
+		return SearchCompletedMsg{
+			Query:   query,
+			Results: SearchVault(notesPath(), companies, query, extension),
 		}
 	}
 }
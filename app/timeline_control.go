@@ -0,0 +1,94 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TimelineControl handles the keys of the timeline view: zooming and paging
+// through periods
+type TimelineControl struct{}
+
+var timelineKeys = []string{"z", "[", "]"}
+
+// Handles reports whether the timeline takes the key.
+func (tc TimelineControl) Handles(key string, m *Model) bool {
+	return m.IsTimelineNavigation() && slices.Contains(timelineKeys, key)
+}
+
+// HandleKey routes timeline keys to their handlers
+func (tc TimelineControl) HandleKey(key string, m *Model) tea.Cmd {
+	switch key {
+	case "z":
+		m.Timeline.Zoom = (m.Timeline.Zoom + 1) % (zoomQuarter + 1)
+	case "]":
+		m.Timeline.Page(1)
+	case "[":
+		m.Timeline.Page(-1)
+	}
+
+	return nil
+}
+
+// IsTimelineNavigation reports whether the timeline view is shown and takes
+// its keys
+func (m *Model) IsTimelineNavigation() bool {
+	return m.ViewManager.IsTimelineView && !m.ViewManager.HideSidebar && !m.IsDetailsView() &&
+		!m.IsNewTaskInputView() && !m.IsFilterView() && !m.IsSearchView()
+}
+
+func (ts TimelineState) anchor() time.Time {
+	if ts.Anchor.IsZero() {
+		return startOfDay(time.Now())
+	}
+	return ts.Anchor
+}
+
+// Window returns the first day shown and the day after the last: the week,
+// month or quarter of the anchor.
+func (ts TimelineState) Window() (time.Time, time.Time) {
+	anchor := ts.anchor()
+
+	switch ts.Zoom {
+	case zoomWeek:
+		start := mondayOf(anchor)
+		return start, start.AddDate(0, 0, 7)
+	case zoomQuarter:
+		month := (anchor.Month()-1)/3*3 + 1
+		start := time.Date(anchor.Year(), month, 1, 0, 0, 0, 0, time.Local)
+		return start, start.AddDate(0, 3, 0)
+	}
+
+	start := time.Date(anchor.Year(), anchor.Month(), 1, 0, 0, 0, 0, time.Local)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// Page moves the anchor a period forward or back.
+func (ts *TimelineState) Page(direction int) {
+	start, _ := ts.Window()
+
+	switch ts.Zoom {
+	case zoomWeek:
+		ts.Anchor = start.AddDate(0, 0, 7*direction)
+	case zoomQuarter:
+		ts.Anchor = start.AddDate(0, 3*direction, 0)
+	default:
+		ts.Anchor = start.AddDate(0, direction, 0)
+	}
+}
+
+// Title names the shown period.
+func (ts TimelineState) Title() string {
+	start, end := ts.Window()
+
+	switch ts.Zoom {
+	case zoomWeek:
+		return start.Format("2 Jan") + " – " + end.AddDate(0, 0, -1).Format("2 Jan 2006")
+	case zoomQuarter:
+		return fmt.Sprintf("Q%d %d", (start.Month()-1)/3+1, start.Year())
+	}
+	return start.Format("January 2006")
+}
@@ -0,0 +1,250 @@
+package app
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type timelineZoom int
+
+const (
+	zoomWeek timelineZoom = iota
+	zoomMonth
+	zoomQuarter
+)
+
+func (z timelineZoom) String() string {
+	switch z {
+	case zoomWeek:
+		return "week"
+	case zoomQuarter:
+		return "quarter"
+	}
+	return "month"
+}
+
+// timelineLabelWidth is the width of the file names left of the bars.
+const timelineLabelWidth = 24
+
+// TimelineState is the zoom of the timeline view and the day its shown
+// period contains, today until it pages.
+type TimelineState struct {
+	Zoom   timelineZoom
+	Anchor time.Time
+}
+
+// timelineBar is a task file on the timeline: from its earliest started or
+// scheduled date to its last completion, or to today while it is open.
+type timelineBar struct {
+	File  string
+	Start time.Time
+	End   time.Time
+	Open  bool
+	Marks []time.Time
+}
+
+// buildTimeline returns the bars of the task files with dates, by start.
+// Completed tasks mark their completion date along the bar.
+func buildTimeline(tasksByFile map[string][]Task, today time.Time) []timelineBar {
+	var bars []timelineBar
+
+	for file, tasks := range tasksByFile {
+		bar := timelineBar{File: file}
+		var last time.Time
+
+		for _, task := range tasks {
+			for _, date := range []string{task.StartDate, task.ScheduledDate, task.CompletedDate} {
+				if day, ok := parseDay(date); ok && (bar.Start.IsZero() || day.Before(bar.Start)) {
+					bar.Start = day
+				}
+			}
+
+			if !task.Completed {
+				bar.Open = true
+				continue
+			}
+
+			if day, ok := parseDay(task.CompletedDate); ok {
+				bar.Marks = append(bar.Marks, day)
+				if day.After(last) {
+					last = day
+				}
+			}
+		}
+
+		if bar.Start.IsZero() {
+			continue
+		}
+
+		bar.End = last
+		if bar.Open || last.IsZero() {
+			bar.Open = true
+			bar.End = startOfDay(today)
+		}
+		if bar.End.Before(bar.Start) {
+			bar.End = bar.Start
+		}
+
+		slices.SortFunc(bar.Marks, func(a, b time.Time) int { return a.Compare(b) })
+		bars = append(bars, bar)
+	}
+
+	slices.SortFunc(bars, func(a, b timelineBar) int {
+		if c := a.Start.Compare(b.Start); c != 0 {
+			return c
+		}
+		return strings.Compare(a.File, b.File)
+	})
+
+	return bars
+}
+
+func parseDay(date string) (time.Time, bool) {
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	return day, err == nil
+}
+
+type TimelineView struct {
+	state     TimelineState
+	bars      []timelineBar
+	extension string
+	width     int
+}
+
+func NewTimelineView(tasksByFile map[string][]Task, state TimelineState, extension string, width int) TimelineView {
+	return TimelineView{
+		state:     state,
+		bars:      buildTimeline(tasksByFile, time.Now()),
+		extension: extension,
+		width:     width,
+	}
+}
+
+func (tv TimelineView) View() string {
+	start, end := tv.state.Window()
+	title := summaryTitleStyle(tv.width).Render("Timeline · " + tv.state.Title() + " (" + tv.state.Zoom.String() + ")")
+
+	var bars []timelineBar
+	for _, bar := range tv.bars {
+		if bar.Start.Before(end) && !bar.End.Before(start) {
+			bars = append(bars, bar)
+		}
+	}
+
+	if len(bars) == 0 {
+		return joinVertical(title, "", loadingTextStyle.Render("No task files in this period"))
+	}
+
+	lines := []string{title, "", tv.renderScale(start, end)}
+	for _, bar := range bars {
+		lines = append(lines, tv.renderBar(bar, start, end))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func (tv TimelineView) gridWidth() int {
+	return max(tv.width-timelineLabelWidth-6, 10)
+}
+
+// column is the grid column of day within the window.
+func (tv TimelineView) column(day, start, end time.Time) int {
+	days := daysBetween(start, end)
+	return daysBetween(start, day) * tv.gridWidth() / days
+}
+
+// renderScale labels the days of a week, the Mondays of a month or the
+// months of a quarter.
+func (tv TimelineView) renderScale(start, end time.Time) string {
+	scale := []rune(strings.Repeat(" ", tv.gridWidth()))
+
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		var label string
+		switch tv.state.Zoom {
+		case zoomWeek:
+			label = day.Format("Mon 2")
+		case zoomMonth:
+			if day.Weekday() == time.Monday || day.Day() == 1 {
+				label = day.Format("Jan 2")
+			}
+		case zoomQuarter:
+			if day.Day() == 1 {
+				label = day.Format("Jan")
+			}
+		}
+
+		column := tv.column(day, start, end)
+		if label == "" || column+len(label) > len(scale) || (column > 0 && scale[column-1] != ' ') {
+			continue
+		}
+		copy(scale[column:], []rune(label))
+	}
+
+	return strings.Repeat(" ", timelineLabelWidth+1) + taskDateStyle().Render(string(scale))
+}
+
+// renderBar draws a file's bar, clipped to the window with arrows where it
+// goes on, and its completions as diamonds.
+func (tv TimelineView) renderBar(bar timelineBar, start, end time.Time) string {
+	width := tv.gridWidth()
+	cells := make([]rune, width)
+	for i := range cells {
+		cells[i] = ' '
+	}
+
+	from := 0
+	if !bar.Start.Before(start) {
+		from = tv.column(bar.Start, start, end)
+	}
+	to := width
+	if bar.End.Before(end) {
+		to = max(tv.column(bar.End.AddDate(0, 0, 1), start, end), from+1)
+	}
+
+	for i := from; i < to && i < width; i++ {
+		cells[i] = '━'
+	}
+	if bar.Start.Before(start) {
+		cells[0] = '◀'
+	}
+	if !bar.End.Before(end) {
+		cells[width-1] = '▶'
+	}
+
+	barStyle := completedTextStyle
+	if bar.Open {
+		barStyle = startedTextStyle
+	}
+
+	marks := make(map[int]bool)
+	for _, mark := range bar.Marks {
+		if !mark.Before(start) && mark.Before(end) {
+			marks[tv.column(mark, start, end)] = true
+		}
+	}
+
+	var line strings.Builder
+	for i, cell := range cells {
+		if marks[i] {
+			line.WriteString(highlightedTextStyle.Render("◆"))
+		} else {
+			line.WriteString(barStyle.Render(string(cell)))
+		}
+	}
+
+	label := truncateString(strings.TrimSuffix(bar.File, tv.extension), timelineLabelWidth)
+	return lipgloss.NewStyle().Width(timelineLabelWidth+1).Render(label) + line.String()
+}
+
+func taskDateStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(taskDateColor)
+}
+
+// daysBetween counts the calendar days from a to b, across DST changes.
+func daysBetween(a, b time.Time) int {
+	return int(math.Round(b.Sub(a).Hours() / 24))
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildTimeline(t *testing.T) {
+	today := day(2024, 3, 20)
+	tasksByFile := map[string][]Task{
+		"Release.md": {
+			{Completed: true, ScheduledDate: "2024-03-04", CompletedDate: "2024-03-06"},
+			{Completed: true, StartDate: "2024-03-02", CompletedDate: "2024-03-12"},
+		},
+		"Migration.md": {
+			{Completed: true, ScheduledDate: "2024-03-05", CompletedDate: "2024-03-07"},
+			{ScheduledDate: "2024-03-08"},
+		},
+		"Someday.md": {
+			{Text: "No dates"},
+		},
+	}
+
+	bars := buildTimeline(tasksByFile, today)
+
+	if len(bars) != 2 {
+		t.Fatalf("expected the 2 files with dates, got %+v", bars)
+	}
+
+	release := bars[0]
+	if release.File != "Release.md" || !release.Start.Equal(day(2024, 3, 2)) || !release.End.Equal(day(2024, 3, 12)) || release.Open {
+		t.Errorf("expected Release to run from Mar 2 to Mar 12, got %+v", release)
+	}
+	if len(release.Marks) != 2 || !release.Marks[0].Equal(day(2024, 3, 6)) {
+		t.Errorf("expected Release's completions to be marked in order, got %v", release.Marks)
+	}
+
+	migration := bars[1]
+	if !migration.Open || !migration.End.Equal(today) || len(migration.Marks) != 1 {
+		t.Errorf("expected the open Migration to run until today, got %+v", migration)
+	}
+}
+
+func TestTimelineStateWindow(t *testing.T) {
+	anchor := day(2024, 5, 15)
+
+	tests := []struct {
+		zoom      timelineZoom
+		wantStart time.Time
+		wantEnd   time.Time
+		wantPage  time.Time
+		wantTitle string
+	}{
+		{zoomWeek, day(2024, 5, 13), day(2024, 5, 20), day(2024, 5, 20), "13 May – 19 May 2024"},
+		{zoomMonth, day(2024, 5, 1), day(2024, 6, 1), day(2024, 6, 1), "May 2024"},
+		{zoomQuarter, day(2024, 4, 1), day(2024, 7, 1), day(2024, 7, 1), "Q2 2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.zoom.String(), func(t *testing.T) {
+			state := TimelineState{Zoom: tt.zoom, Anchor: anchor}
+
+			start, end := state.Window()
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("expected %s to %s, got %s to %s", tt.wantStart, tt.wantEnd, start, end)
+			}
+			if title := state.Title(); title != tt.wantTitle {
+				t.Errorf("expected title %q, got %q", tt.wantTitle, title)
+			}
+
+			state.Page(1)
+			if !state.Anchor.Equal(tt.wantPage) {
+				t.Errorf("expected the next period to start %s, got %s", tt.wantPage, state.Anchor)
+			}
+		})
+	}
+}
+
+func TestTimelineViewRendersBarsInWindow(t *testing.T) {
+	tasksByFile := map[string][]Task{
+		"Release.md": {{Completed: true, ScheduledDate: "2024-03-04", CompletedDate: "2024-03-06"}},
+		"Archive.md": {{Completed: true, ScheduledDate: "2023-01-04", CompletedDate: "2023-01-06"}},
+	}
+
+	view := NewTimelineView(tasksByFile, TimelineState{Zoom: zoomMonth, Anchor: day(2024, 3, 1)}, ".md", 100).View()
+
+	if !strings.Contains(view, "Release") || strings.Contains(view, "Archive") {
+		t.Errorf("expected only the files of March 2024, got\n%s", view)
+	}
+	if !strings.Contains(view, "◆") || !strings.Contains(view, "Mar 4") {
+		t.Errorf("expected the completion marker and the week labels, got\n%s", view)
+	}
+}
@@ -1,12 +1,36 @@
 package app
 
 import (
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/log"
 )
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+
+	// Keys select and update tasks, and syncs write pulled changes, so the
+	// history pane rereads the log after them rather than on every render.
+	switch msg.(type) {
+	case tea.KeyMsg, CalDAVSyncedMsg:
+		m.refreshHistory()
+	}
+
+	// Handlers only mark the file list as stale; the load itself runs off
+	// the update loop.
+	if m.FileManager.refreshRequested {
+		cmd = tea.Batch(cmd, m.refreshFilesCmd())
+	}
+
+	if loadCmd := m.loadSelectedFileCmd(); loadCmd != nil {
+		cmd = tea.Batch(cmd, loadCmd)
+	}
+
+	return model, cmd
+}
+
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
@@ -19,19 +43,32 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if key == "ctrl+c" {
 			return m, tea.Quit
 		} else if key == "q" {
-			if m.IsAddTaskView() {
-				m.NewTaskInput, cmd = m.NewTaskInput.Update(msg)
-				return m, cmd
-			} else if m.IsAddSubTaskView() {
+			if m.IsNewTaskInputView() {
 				m.NewTaskInput, cmd = m.NewTaskInput.Update(msg)
 				return m, cmd
 			} else if m.IsFilterView() {
 				m.FilterInput, cmd = m.FilterInput.Update(msg)
 				return m, cmd
+			} else if m.IsSearchView() && m.SearchInput.Focused() {
+				m.SearchInput, cmd = m.SearchInput.Update(msg)
+				return m, cmd
 			}
 
 			return m, tea.Quit
-		} else if m.IsAddTaskView() || m.IsFilterView() || m.IsAddSubTaskView() {
+		} else if m.IsSearchView() {
+			if !m.SearchInput.Focused() {
+				return m, SearchControl{}.HandleKey(key, m)
+			}
+
+			if key == "esc" {
+				return m, SearchControl{}.CloseSearch(m)
+			} else if key == "enter" {
+				return m, SearchControl{}.SubmitSearch(m)
+			}
+
+			m.SearchInput, cmd = m.SearchInput.Update(msg)
+			cmds = append(cmds, cmd)
+		} else if m.IsNewTaskInputView() || m.IsFilterView() {
 			factory := NewKeyCommandFactory()
 			if key == "esc" {
 				cmdResult := factory.CreateKeyCommand("esc").Execute(m)
@@ -43,7 +80,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			if m.IsFilterView() {
 				m.FilterInput, cmd = m.FilterInput.Update(msg)
-				m.TaskManager.TaskCollection.FilterValue = m.FilterInput.Value()
+				m.ApplyFilter(m.FilterInput.Value())
 				cmds = append(cmds, cmd)
 			} else {
 				if key == "[" {
@@ -65,6 +102,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmdResult := factory.CreateKeyCommand("shift+tab").Execute(m)
 				cmds = append(cmds, cmdResult)
 			}
+		} else if (QuickPanelControl{}).Handles(key, m) {
+			cmds = append(cmds, QuickPanelControl{}.HandleKey(key, m))
+		} else if (DashboardControl{}).Handles(key, m) {
+			cmds = append(cmds, DashboardControl{}.HandleKey(key, m))
+		} else if (CalendarControl{}).Handles(key, m) {
+			cmds = append(cmds, CalendarControl{}.HandleKey(key, m))
+		} else if (TimelineControl{}).Handles(key, m) {
+			cmds = append(cmds, TimelineControl{}.HandleKey(key, m))
 		} else {
 			keyCommandFactory := NewKeyCommandFactory()
 			keyCommand := keyCommandFactory.CreateKeyCommand(key)
@@ -100,8 +145,93 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		// Reload file and tasks after editing
-		log.Info("Editor closed, reloading tasks")
-		m.FileManager.FetchTasks(&m.DirectoryManager, &m.TaskManager)
+		log.Info("Editor closed, reloading files")
+		m.FileManager.RequestRefresh()
+		return m, nil
+
+	case FileLoadedMsg:
+		if msg.Err != nil {
+			delete(m.FileManager.contentRequested, msg.File.FullPath)
+			m.Errors = append(m.Errors, "Failed to load "+msg.File.Name+": "+msg.Err.Error())
+			return m, nil
+		}
+
+		m.FileManager.StoreContent(msg.File, msg.Content)
+		return m, nil
+
+	case FilesRefreshedMsg:
+		if m.FileManager.ApplyLoadedFiles(msg, &m.DirectoryManager, &m.TaskManager) {
+			m.Calendar.SetEvents(msg.Company.DisplayName, msg.Events)
+			return m, m.companyTasksCmd()
+		}
+		return m, nil
+
+	case CompanyTasksLoadedMsg:
+		m.TaskManager.CompanyCollections = msg.Collections
+		return m, nil
+
+	case ReminderTickMsg:
+		cmds = append(cmds, reminderTickCmd())
+		for _, reminder := range m.Reminders.Due(msg.Time) {
+			cmds = append(cmds, m.reminderCmd(reminder, msg.Time))
+		}
+		return m, tea.Batch(cmds...)
+
+	case ReminderDueMsg:
+		banner := msg.Banner
+		m.Reminders.Banner = &banner
+		return m, nil
+
+	case DashboardLoadedMsg:
+		m.Dashboard.Cards = msg.Cards
+		m.Dashboard.Loading = false
+		if m.Dashboard.Cursor >= len(msg.Cards) {
+			m.Dashboard.Cursor = 0
+		}
+		return m, nil
+
+	case QuickPanelLoadedMsg:
+		if msg.Company == m.GetCurrentCompanyName() {
+			m.QuickPanel = QuickPanelState{Company: msg.Company, Meetings: msg.Meetings, People: msg.People}
+		}
+		return m, nil
+
+	case SyncTickMsg:
+		cmds = append(cmds, syncTickCmd())
+		if m.Sync.Status != syncRunning {
+			cmds = append(cmds, m.syncCmd())
+		}
+		return m, tea.Batch(cmds...)
+
+	case CalDAVSyncedMsg:
+		m.Sync.ApplySync(msg)
+		if len(msg.Result.Changes) > 0 {
+			if err := applySyncChanges(&m.FileManager, msg.Result.Changes, msg.Records); err != nil {
+				m.Errors = append(m.Errors, err.Error())
+			}
+			m.FileManager.RequestRefresh()
+		}
+		saveSyncRecords(msg.Records)
+		return m, nil
+
+	case LinkGraphBuiltMsg:
+		m.FileManager.ApplyLinkGraph(msg)
+		return m, nil
+
+	case spinner.TickMsg:
+		if !m.FileManager.IsLoading() {
+			return m, nil
+		}
+
+		m.Spinner, cmd = m.Spinner.Update(msg)
+		return m, cmd
+
+	case SearchCompletedMsg:
+		if msg.Query == m.Search.Query {
+			m.Search.Results = msg.Results
+			m.Search.Cursor = 0
+			m.Search.Searching = false
+		}
 		return m, nil
 
 	case ErrorOccurredMsg:
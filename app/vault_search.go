@@ -0,0 +1,252 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/log"
+)
+
+const (
+	maxSearchResults    = 100
+	searchSnippetLength = 80
+)
+
+// SearchResult is a note matching a vault search. Line is the 1-based line of
+// the best matching line in the file, which Snippet shows.
+type SearchResult struct {
+	Company   Company
+	Category  string
+	Filename  string
+	FullPath  string
+	Line      int
+	Snippet   string
+	Matches   int
+	Score     int
+	UpdatedAt time.Time
+}
+
+// SearchState holds the vault search shown by the search view.
+type SearchState struct {
+	Query     string
+	Results   []SearchResult
+	Cursor    int
+	Searching bool
+}
+
+func (s SearchState) SelectedResult() (SearchResult, bool) {
+	if s.Cursor < 0 || s.Cursor >= len(s.Results) {
+		return SearchResult{}, false
+	}
+	return s.Results[s.Cursor], true
+}
+
+// SearchVault scans every category folder of every company under root for
+// notes containing all query terms and returns them best match first.
+func SearchVault(root string, companies []Company, query string, extension string) []SearchResult {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var results []SearchResult
+
+	for _, company := range companies {
+		for _, category := range company.SubFolders {
+			path := filepath.Join(root, company.FolderPathName, strings.ToLower(category))
+
+			entries, err := os.ReadDir(path)
+			if err != nil {
+				log.Debug("Skipping search folder", "path", path, "error", err)
+				continue
+			}
+
+			for _, entry := range entries {
+				if entry.IsDir() || !strings.HasSuffix(entry.Name(), extension) {
+					continue
+				}
+
+				fullPath := filepath.Join(path, entry.Name())
+				content, err := os.ReadFile(fullPath)
+				if err != nil {
+					log.Warn("Failed to read file", "path", fullPath, "error", err)
+					continue
+				}
+
+				result, ok := searchFileContent(entry.Name(), string(content), terms)
+				if !ok {
+					continue
+				}
+
+				if info, err := entry.Info(); err == nil {
+					result.UpdatedAt = info.ModTime()
+				}
+
+				result.Company = company
+				result.Category = category
+				result.FullPath = fullPath
+				results = append(results, result)
+			}
+		}
+	}
+
+	rankSearchResults(results)
+
+	if len(results) > maxSearchResults {
+		results = results[:maxSearchResults]
+	}
+
+	return results
+}
+
+func searchTerms(query string) []string {
+	return strings.Fields(strings.ToLower(query))
+}
+
+// searchFileContent scores a note against the lowercase terms. Every term
+// must appear in the filename or the content. Filename hits, headings and the
+// full query as a phrase rank higher than scattered mentions.
+func searchFileContent(filename string, content string, terms []string) (SearchResult, bool) {
+	lowerName := strings.ToLower(filename)
+	lowerContent := strings.ToLower(content)
+	score := 0
+	matches := 0
+
+	for _, term := range terms {
+		inName := strings.Contains(lowerName, term)
+		count := strings.Count(lowerContent, term)
+
+		if !inName && count == 0 {
+			return SearchResult{}, false
+		}
+
+		if inName {
+			score += 20
+		}
+
+		matches += count
+		score += min(count, 10)
+	}
+
+	if len(terms) > 1 && strings.Contains(lowerContent, strings.Join(terms, " ")) {
+		score += 10
+	}
+
+	result := SearchResult{Filename: filename, Matches: matches}
+	bestTerms, bestCount := 0, 0
+
+	for index, line := range strings.Split(content, "\n") {
+		lowerLine := strings.ToLower(line)
+		lineTerms, lineCount := 0, 0
+
+		for _, term := range terms {
+			if count := strings.Count(lowerLine, term); count > 0 {
+				lineTerms++
+				lineCount += count
+			}
+		}
+
+		if lineTerms == 0 {
+			continue
+		}
+
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			score += 5 * lineTerms
+		}
+
+		if lineTerms > bestTerms || (lineTerms == bestTerms && lineCount > bestCount) {
+			bestTerms, bestCount = lineTerms, lineCount
+			result.Line = index + 1
+			result.Snippet = searchSnippet(line, terms)
+		}
+	}
+
+	if result.Line == 0 {
+		result.Line = 1
+	}
+
+	result.Score = score
+	return result, true
+}
+
+// searchSnippet trims a matching line to a window starting a little before
+// its first match.
+func searchSnippet(line string, terms []string) string {
+	line = strings.TrimSpace(line)
+	lowerLine := strings.ToLower(line)
+
+	first := len(line)
+	for _, term := range terms {
+		if index := strings.Index(lowerLine, term); index >= 0 && index < first {
+			first = index
+		}
+	}
+
+	start := utf8.RuneCountInString(line[:min(first, len(line))]) - 20
+	runes := []rune(line)
+	start = max(start, 0)
+	end := min(start+searchSnippetLength, len(runes))
+
+	snippet := string(runes[start:end])
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+
+	return snippet
+}
+
+func rankSearchResults(results []SearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if !results[i].UpdatedAt.Equal(results[j].UpdatedAt) {
+			return results[i].UpdatedAt.After(results[j].UpdatedAt)
+		}
+		return results[i].FullPath < results[j].FullPath
+	})
+}
+
+// searchHighlightRanges returns the byte ranges of the terms in text, merged
+// where they overlap, in order. Text whose lowercase form changes length is
+// left unhighlighted since the offsets would not line up.
+func searchHighlightRanges(text string, terms []string) [][2]int {
+	lowerText := strings.ToLower(text)
+	if len(lowerText) != len(text) {
+		return nil
+	}
+
+	var ranges [][2]int
+
+	for _, term := range terms {
+		for offset := 0; ; {
+			index := strings.Index(lowerText[offset:], term)
+			if index < 0 {
+				break
+			}
+			start := offset + index
+			ranges = append(ranges, [2]int{start, start + len(term)})
+			offset = start + len(term)
+		}
+	}
+
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+
+	var merged [][2]int
+	for _, r := range ranges {
+		if len(merged) > 0 && r[0] <= merged[len(merged)-1][1] {
+			merged[len(merged)-1][1] = max(merged[len(merged)-1][1], r[1])
+			continue
+		}
+		merged = append(merged, r)
+	}
+
+	return merged
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSearchVault(t *testing.T) {
+	root := t.TempDir()
+	clerky := Company{DisplayName: "Clerky", FolderPathName: "clerky", SubFolders: []string{"tasks", "meetings"}}
+	qvest := Company{DisplayName: "Qvest.US", FolderPathName: "qvest_us", SubFolders: []string{"people"}}
+
+	files := map[string]string{
+		"clerky/meetings/2024-03-01.md": "# Standup\nDiscussed the roadmap\nRoadmap review next week\n",
+		"clerky/meetings/roadmap.md":    "Nothing relevant here\n",
+		"clerky/tasks/billing.md":       "- [ ] Fix invoices\n",
+		"qvest_us/people/Jane Doe.md":   "Owns the roadmap planning\n",
+		"qvest_us/tasks/hidden.md":      "roadmap in a folder that is not configured\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results := SearchVault(root, []Company{clerky, qvest}, "Roadmap", ".md")
+
+	var names []string
+	for _, result := range results {
+		names = append(names, result.Filename)
+	}
+
+	expected := []string{"roadmap.md", "2024-03-01.md", "Jane Doe.md"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+
+	meeting := results[1]
+	if meeting.Line != 2 || meeting.Snippet != "Discussed the roadmap" || meeting.Matches != 2 {
+		t.Errorf("expected line 2 with 2 matches, got line %d %q with %d matches", meeting.Line, meeting.Snippet, meeting.Matches)
+	}
+
+	if meeting.Company.DisplayName != "Clerky" || meeting.Category != "meetings" {
+		t.Errorf("expected Clerky meetings, got %s %s", meeting.Company.DisplayName, meeting.Category)
+	}
+
+	if results := SearchVault(root, []Company{clerky, qvest}, "roadmap invoices", ".md"); len(results) != 0 {
+		t.Errorf("expected every term to be required, got %v", results)
+	}
+}
+
+func TestSearchFileContentPrefersLinesWithMoreTerms(t *testing.T) {
+	content := "budget notes\nplanning the q3 budget\nq3 offsite"
+
+	result, ok := searchFileContent("notes.md", content, searchTerms("Q3 budget"))
+	if !ok {
+		t.Fatal("expected a match")
+	}
+
+	if result.Line != 2 || result.Snippet != "planning the q3 budget" {
+		t.Errorf("expected line 2, got line %d %q", result.Line, result.Snippet)
+	}
+}
+
+func TestSearchHighlightRanges(t *testing.T) {
+	ranges := searchHighlightRanges("Roadmap and road trips", []string{"road", "roadmap"})
+	expected := [][2]int{{0, 7}, {12, 16}}
+
+	if !reflect.DeepEqual(ranges, expected) {
+		t.Errorf("expected %v, got %v", expected, ranges)
+	}
+}
@@ -18,6 +18,18 @@ func (m *Model) View() string {
 func ViewHandler(m *Model) string {
 	content := "Something is wrong"
 
+	if m.IsSearchView() {
+		return joinVertical(renderNavbar(m), renderSearch(m), renderErrors(m))
+	}
+
+	if m.ViewManager.IsQuickPanelView {
+		return joinVertical(renderNavbar(m), renderQuickPanel(m), renderErrors(m))
+	}
+
+	if m.ViewManager.IsDashboardView {
+		return joinVertical(renderNavbar(m), renderDashboard(m), renderErrors(m))
+	}
+
 	if m.IsCategoryView() {
 		content = renderList(m, m.CategoryNames())
 	} else if m.IsDetailsView() {
@@ -29,7 +41,7 @@ func ViewHandler(m *Model) string {
 		}
 	}
 
-	return joinVertical(renderNavbar(m), renderFilterInput(m), content, renderErrors(m))
+	return joinVertical(renderNavbar(m), renderFilterInput(m), content, renderTaskHistory(m), renderErrors(m))
 }
 
 func renderErrors(m *Model) string {
@@ -64,6 +76,10 @@ func renderList(m *Model, items []string) string {
 		list = joinVertical(list, createListItem(item, index, cursor))
 	}
 
+	if loading := renderLoading(m); loading != "" {
+		list = joinVertical(list, "", loading)
+	}
+
 	sidebar := sidebarStyle(m.ViewManager.SidebarWidth, m.ViewManager.SidebarHeight).Render(joinVertical(list))
 
 	summaryView := buildSummaryView(m, m.ViewManager.HideSidebar)
@@ -84,7 +100,7 @@ func buildSummaryView(m *Model, hiddenSidebar bool) string {
 	summaryView := ""
 	period := "daily"
 
-	if m.IsAddTaskView() || m.IsAddSubTaskView() {
+	if m.IsNewTaskInputView() {
 		summaryView = m.NewTaskInput.View() + "\n"
 
 		if hasUnclosedDoubleSquareBrackets(m.NewTaskInput.Value()) {
@@ -99,7 +115,7 @@ func buildSummaryView(m *Model, hiddenSidebar bool) string {
 			period = "weekly"
 		}
 
-		if hiddenSidebar {
+		if hiddenSidebar && !m.ViewManager.IsCalendarView {
 			summaryView = kanbanSummaryView(m, period)
 		} else {
 			summaryView = taskSummaryToView(m, period)
@@ -125,12 +141,26 @@ func kanbanSummaryView(m *Model, period string) string {
 }
 
 func taskSummaryToView(m *Model, period string) string {
+	if m.ViewManager.IsStatsView {
+		metrics := computeFlowMetrics(m.GetCurrentCompanyName(), m.TaskManager.TaskCollection.allTasks(), flowWeeks, time.Now())
+		return renderFlowMetrics(metrics, m.ViewManager.DetailsViewWidth)
+	}
+
+	if m.ViewManager.IsTimelineView {
+		return NewTimelineView(
+			m.TaskManager.TaskCollection.GetTasksByFile(),
+			m.Timeline,
+			m.FileManager.FileExtension,
+			m.ViewManager.DetailsViewWidth,
+		).View()
+	}
+
 	if m.ViewManager.IsCalendarView {
 		calendarView := NewCalendarView(
 			m.TaskManager.TaskCollection.allTasks(),
 			m.ViewManager.DetailsViewWidth,
 			m.ViewManager.DetailsViewHeight,
-		)
+		).WithState(m.Calendar).WithEvents(m.Calendar.Events[m.GetCurrentCompanyName()]).WithFocus(m.IsCalendarNavigation())
 		return calendarView.View()
 	}
 
@@ -149,6 +179,13 @@ func taskSummaryToView(m *Model, period string) string {
 
 	view := BuildSummaryView(m, keys, tasksByFile, m.ViewManager.DetailsViewWidth, summaryDate)
 
+	if period == "daily" {
+		day, _ := time.ParseInLocation("2006-01-02", summaryDate, time.Local)
+		if events := renderEvents(m.Calendar.EventsOn(m.GetCurrentCompanyName(), day), m.ViewManager.DetailsViewWidth); events != "" {
+			view = joinVertical(events, view)
+		}
+	}
+
 	containerTitle := taskSummaryContainerStyle(m.ViewManager.DetailsViewWidth, containerTitleHeight).Height(2).PaddingBottom(0).Render(summaryTitle(m, period))
 	renderedView := taskSummaryContainerStyle(m.ViewManager.DetailsViewWidth, viewHeight).PaddingTop(0).Render(view)
 
@@ -205,17 +242,24 @@ func summaryTitle(m *Model, period string) string {
 	return title
 }
 
+// sortTaskKeys returns the files of tasksByFile in name order.
 func sortTaskKeys(tasksByFile map[string][]Task) []string {
 	keys := make([]string, 0, len(tasksByFile))
 	for k := range tasksByFile {
 		keys = append(keys, k)
 	}
 
+	sort.Strings(keys)
 	return keys
 }
 
 func renderFiles(m *Model) string {
+	loading := renderLoading(m)
+
 	if !m.HasFiles() {
+		if loading != "" {
+			return listContainerStyle(m.ViewManager.SidebarWidth, m.ViewManager.SidebarHeight, false).Render(loading)
+		}
 		return "No files found"
 	}
 
@@ -224,6 +268,10 @@ func renderFiles(m *Model) string {
 
 	list, itemDetails := BuildFilesView(m, m.ViewManager.HideSidebar)
 
+	if list != "" && loading != "" {
+		list = joinVertical(loading, "", list)
+	}
+
 	itemDetailsContainer := filesItemDetailsContainerStyle(m.ViewManager.DetailsViewWidth, m.ViewManager.DetailsViewHeight).Render(itemDetails)
 
 	if list != "" {
@@ -235,6 +283,17 @@ func renderFiles(m *Model) string {
 	return joinVertical(container)
 }
 
+// renderLoading shows the spinner and how many notes have been read while
+// files load in the background.
+func renderLoading(m *Model) string {
+	if !m.FileManager.IsLoading() {
+		return ""
+	}
+
+	done, total := m.FileManager.LoadProgress()
+	return loadingTextStyle.Render(fmt.Sprintf("%s Loading %d/%d", m.Spinner.View(), done, total))
+}
+
 func renderNavbar(m *Model) string {
 	companyColor := m.DirectoryManager.SelectedCompany.Color
 	textStyle := navbarTextStyle(companyColor)
@@ -245,11 +304,23 @@ func renderNavbar(m *Model) string {
 	if m.IsCategoryView() {
 		navbar = textStyle.Render(m.GetCurrentCompanyName())
 	} else if m.IsDetailsView() {
-		navbar = textStyle.Render(m.GetCurrentCompanyName() + " > " + m.DirectoryManager.SelectedCategory + " > " + m.FileManager.SelectedFile.Name)
+		category := m.DirectoryManager.SelectedCategory
+		if view, ok := m.DirectoryManager.CurrentSavedView(); ok {
+			category = view.Label()
+		}
+		navbar = textStyle.Render(m.GetCurrentCompanyName() + " > " + category + " > " + m.FileManager.SelectedFile.Name)
 	}
 
 	navbarView := joinVertical(style.Render(navbar))
 
+	if banner := renderReminderBanner(m); banner != "" {
+		navbarView = joinVertical(navbarView, style.Render(banner))
+	}
+
+	if status := renderSyncStatus(m); status != "" {
+		navbarView = joinVertical(navbarView, style.Render(status))
+	}
+
 	if m.ViewManager.ShowCompanies {
 		navbarView = joinHorizontal(navbar, renderCompanies(m, m.CategoryNames()))
 	}
@@ -264,11 +335,96 @@ func renderFilterInput(m *Model) string {
 		m.FilterInput.Focus()
 	}
 
-	return filterInputStyle(m.DirectoryManager.SelectedCompany.Color).Render(m.FilterInput.View())
+	filterInput := filterInputStyle(m.DirectoryManager.SelectedCompany.Color).Render(m.FilterInput.View())
+
+	if _, _, _, err := parseFilterScope(m.FilterInput.Value(), &m.DirectoryManager); err != nil && m.ViewManager.IsFilterView {
+		filterInput = joinVertical(filterInput, filterErrorStyle().Render("Invalid folder: "+err.Error()))
+	} else if err := m.TaskManager.TaskCollection.FilterError(); err != nil {
+		filterInput = joinVertical(filterInput, filterErrorStyle().Render("Invalid filter: "+err.Error()))
+	}
+
+	tagFacets := renderTagFacets(m)
+	if !m.ViewManager.IsFilterView || tagFacets == "" {
+		return filterInput
+	}
+
+	return joinVertical(filterInput, tagFacets)
+}
+
+// renderSearch shows the vault search input and its ranked results, keeping
+// the selected result in view.
+func renderSearch(m *Model) string {
+	input := filterInputStyle(m.DirectoryManager.SelectedCompany.Color).Render(m.SearchInput.View())
+	results := m.Search.Results
+
+	status := fmt.Sprintf("%d notes match %q · enter: open · e: vim · o: obsidian · /: new search · esc: close", len(results), m.Search.Query)
+	if m.Search.Searching {
+		status = "Searching all notes..."
+	} else if m.Search.Query == "" {
+		status = "Type a query and press enter to search every company's notes"
+	} else if len(results) == 0 {
+		status = fmt.Sprintf("No notes match %q", m.Search.Query)
+	}
+
+	visible := max((m.ViewManager.Height-heightOffset)/3, 5)
+	start := max(m.Search.Cursor-visible+1, 0)
+	end := min(start+visible, len(results))
+	terms := searchTerms(m.Search.Query)
+
+	var items []string
+	for index := start; index < end; index++ {
+		result := results[index]
+		titleStyle := defaultTextStyle
+		if index == m.Search.Cursor {
+			titleStyle = highlightedTextStyle
+		}
+
+		title := fmt.Sprintf("%s › %s:%d", result.Category, result.Filename, result.Line)
+		if result.Matches > 1 {
+			title += fmt.Sprintf(" (%d matches)", result.Matches)
+		}
+
+		company := navbarTextStyle(result.Company.Color).Render(result.Company.DisplayName + " › ")
+		snippet := highlightSearchSnippet(result.Snippet, terms)
+		items = append(items, searchResultStyle().Render(joinVertical(company+titleStyle.Render(title), snippet)))
+	}
+
+	return joinVertical(input, searchStatusStyle().Render(status), joinVertical(items...))
+}
+
+func highlightSearchSnippet(snippet string, terms []string) string {
+	var highlighted strings.Builder
+	last := 0
+
+	for _, match := range searchHighlightRanges(snippet, terms) {
+		highlighted.WriteString(searchSnippetStyle.Render(snippet[last:match[0]]))
+		highlighted.WriteString(searchMatchStyle.Render(snippet[match[0]:match[1]]))
+		last = match[1]
+	}
+
+	highlighted.WriteString(searchSnippetStyle.Render(snippet[last:]))
+	return highlighted.String()
+}
+
+// renderTagFacets lists the tags available in the current company, highlighting
+// the ones already used as facets in the filter.
+func renderTagFacets(m *Model) string {
+	activeTags := m.TaskManager.TaskCollection.FilterQuery().Tags()
+
+	var chips []string
+	for _, tag := range m.TaskManager.TaskCollection.Tags() {
+		if containsFold(activeTags, tag) {
+			chips = append(chips, tagChipStyle(colorForTag(tag)).Render("#"+tag))
+		} else {
+			chips = append(chips, inactiveTagChipStyle().Render("#"+tag))
+		}
+	}
+
+	return joinHorizontal(chips...)
 }
 
 func renderTasks(m *Model) string {
-	if m.ViewManager.IsAddTaskView || m.ViewManager.IsAddSubTaskView {
+	if m.IsNewTaskInputView() {
 		addTaskView := m.NewTaskInput.View()
 
 		if hasUnclosedDoubleSquareBrackets(m.NewTaskInput.Value()) {
@@ -27,41 +27,47 @@ type KanbanItem struct {
 }
 
 func BuildKanbanSummaryView(m *Model, keys []string, tasksByFile map[string][]Task, width int, date string) string {
-	inactiveList, activeList, completedList := makeKanbanLists(m, keys, tasksByFile)
-	setKanbanTasksCounts(inactiveList, activeList, completedList, m)
+	columns := m.ViewManager.Boards()
 
-	activeBoard := renderBoard("Active", activeList, m, m.ViewManager.KanbanListCursor == 1)
-	completedBoard := renderBoard("Completed", completedList, m, m.ViewManager.KanbanListCursor == 2)
-	inactiveBoard := renderBoard("Inactive", inactiveList, m, m.ViewManager.KanbanListCursor == 0)
+	if grouping := m.ViewManager.KanbanSwimlanes; grouping != "" {
+		var lanes []kanbanLane
+		if grouping == "company" {
+			lanes = companyKanbanLanes(m, columns)
+		} else {
+			lanes = makeKanbanLanes(m, columns, grouping, keys, tasksByFile, m.TaskManager.TaskCollection.FileTags)
+		}
+		setKanbanTasksCounts(kanbanLaneColumns(lanes, len(columns)), m)
+		return renderKanbanLanes(m, columns, lanes)
+	}
+
+	lists := makeKanbanLists(m, columns, keys, tasksByFile)
+	setKanbanTasksCounts(lists, m)
+
+	var boards []string
+	for index, column := range columns {
+		boards = append(boards, renderBoard(column, lists[index], len(columns), m, m.ViewManager.KanbanListCursor == index))
+	}
 
-	return joinHorizontal(inactiveBoard, activeBoard, completedBoard)
+	return joinHorizontal(boards...)
 }
 
-func makeKanbanLists(m *Model, keys []string, tasksByFile map[string][]Task) ([]KanbanItem, []KanbanItem, []KanbanItem) {
-	activeList := []KanbanItem{}
-	completedList := []KanbanItem{}
-	inactiveList := []KanbanItem{}
+// makeKanbanLists places each task on the first column matching it.
+func makeKanbanLists(m *Model, columns []KanbanColumn, keys []string, tasksByFile map[string][]Task) [][]KanbanItem {
+	lists := make([][]KanbanItem, len(columns))
+	fileTags := m.TaskManager.TaskCollection.FileTags
 
 	for _, key := range keys {
 		tasks := tasksByFile[key]
 
 		for _, task := range tasks {
-			if task.IsScheduledForFuture(m.TaskManager.DailySummaryDate) {
-				inactiveList = addTaskOrCreateKanbanItem(inactiveList, key, task)
-				continue
-			}
-
-			if task.Completed {
-				completedList = addTaskOrCreateKanbanItem(completedList, key, task)
-			} else if task.Started || task.Scheduled {
-				activeList = addTaskOrCreateKanbanItem(activeList, key, task)
-			} else if task.ScheduledDate == "" {
-				inactiveList = addTaskOrCreateKanbanItem(inactiveList, key, task)
+			index := kanbanColumnIndex(columns, task, key, fileTags[key], m.TaskManager.DailySummaryDate)
+			if index != -1 {
+				lists[index] = addTaskOrCreateKanbanItem(lists[index], key, task)
 			}
 		}
 	}
 
-	return inactiveList, activeList, completedList
+	return lists
 }
 
 func addTaskOrCreateKanbanItem(list []KanbanItem, filename string, task Task) []KanbanItem {
@@ -75,25 +81,21 @@ func addTaskOrCreateKanbanItem(list []KanbanItem, filename string, task Task) []
 	return append(list, KanbanItem{filename: filename, tasks: []Task{task}})
 }
 
-func setKanbanTasksCounts(inactiveList, activeList, completedList []KanbanItem, m *Model) {
-	if m.ViewManager.KanbanListCursor == 0 {
-		tasksCount := 0
-		for _, item := range inactiveList {
-			tasksCount += len(item.tasks)
-		}
-		m.ViewManager.KanbanTasksCount = tasksCount
-	} else if m.ViewManager.KanbanListCursor == 1 {
-		tasksCount := 0
-		for _, item := range activeList {
-			tasksCount += len(item.tasks)
-		}
-		m.ViewManager.KanbanTasksCount = tasksCount
-	} else if m.ViewManager.KanbanListCursor == 2 {
-		tasksCount := 0
-		for _, item := range completedList {
-			tasksCount += len(item.tasks)
-		}
-		m.ViewManager.KanbanTasksCount = tasksCount
+// setKanbanTasksCounts counts the tasks of the selected list and selects the
+// task under the cursor, so task operations act on the highlighted card.
+func setKanbanTasksCounts(lists [][]KanbanItem, m *Model) {
+	if m.ViewManager.KanbanListCursor >= len(lists) {
+		return
+	}
+
+	var tasks []Task
+	for _, item := range lists[m.ViewManager.KanbanListCursor] {
+		tasks = append(tasks, item.tasks...)
+	}
+
+	m.ViewManager.KanbanTasksCount = len(tasks)
+	if cursor := m.ViewManager.KanbanTaskCursor; cursor >= 0 && cursor < len(tasks) {
+		m.SelectTask(tasks[cursor])
 	}
 }
 
@@ -140,10 +142,10 @@ func renderKanbanList(m *Model, kanbanList []KanbanItem, boardWidth int, selecte
 	return newViewport.View()
 }
 
-func renderBoard(title string, list []KanbanItem, m *Model, selectedBoard bool) string {
-	boardWidth := (m.ViewManager.DetailsViewWidth - 6) / 3
+func renderBoard(column KanbanColumn, list []KanbanItem, count int, m *Model, selectedBoard bool) string {
+	boardWidth := (m.ViewManager.DetailsViewWidth - 2*count) / count
 
-	renderedTitle := kanbanBoardTitleStyle(colorForTitle(title)).Render(title)
+	renderedTitle := kanbanBoardTitleStyle(column.Color).Render(column.Title)
 	renderedList := renderKanbanList(m, list, boardWidth, selectedBoard)
 
 	return boardContainerStyle(boardWidth, m.ViewManager.DetailsViewHeight, selectedBoard).Render(joinVertical(renderedTitle, renderedList))
@@ -188,32 +190,31 @@ func BuildTasksForFileView(m *Model, tasks []Task, date string, cursor int) stri
 func BuildFilesView(m *Model, hiddenSidebar bool) (string, string) {
 	var listItems []string
 	itemDetails := ""
-	completedList := ""
-	activeList := ""
-	inactiveList := ""
 
 	for index, file := range m.FileManager.Files {
 		style := defaultTextStyle
 
 		if index == m.FileManager.FilesCursor {
 			style = highlightedTextStyle
-			itemDetails = file.Content
 			m.FileManager.SelectedFile = file
-		}
-
-		line := file.FileNameWithoutExtension(m.FileManager.FileExtension)
 
-		if m.DirectoryManager.SelectedCategory == "tasks" {
-			if index > 15 {
-				break
+			if content, ok := m.FileManager.FileContent(file); ok {
+				itemDetails = content
+			} else {
+				itemDetails = "Loading..."
 			}
-			list, _, _, _ := buildTaskFilesView(m, line, index, file, style, activeList, completedList, inactiveList)
-			return list, itemDetails
-		} else {
+		}
+
+		if m.DirectoryManager.SelectedCategory != "tasks" {
+			line := file.FileNameWithoutExtension(m.FileManager.FileExtension)
 			listItems = append(listItems, style.Render(line))
 		}
 	}
 
+	if m.DirectoryManager.SelectedCategory == "tasks" && len(m.FileManager.Files) > 0 {
+		listItems = append(listItems, buildTaskFilesView(m))
+	}
+
 	list := ""
 	if len(listItems) > 0 {
 		list = joinVertical(listItems...)
@@ -223,7 +224,7 @@ func BuildFilesView(m *Model, hiddenSidebar bool) (string, string) {
 		list = ""
 	}
 
-	if m.IsAddTaskView() || m.IsAddSubTaskView() {
+	if m.IsNewTaskInputView() {
 		itemDetails = m.NewTaskInput.View()
 
 		// Only render suggestions if they're active (state set in Update, not here)
@@ -259,8 +260,27 @@ func BuildFilesView(m *Model, hiddenSidebar bool) (string, string) {
 			}
 		}
 	} else {
+		link, hasLink := m.SelectedLink()
+		hasLink = hasLink && m.IsLinkNavigation()
+		if hasLink {
+			itemDetails = highlightLink(itemDetails, link)
+		}
+
 		markdown := renderMarkdown(itemDetails)
+		if person := personContextView(m); person != "" {
+			markdown = joinVertical(markdown, person, "")
+		}
+		if burndown := burndownView(m); burndown != "" {
+			markdown = joinVertical(markdown, burndown, "")
+		}
+		if backlinks := renderBacklinks(m.NoteLinks(), link, hasLink); backlinks != "" {
+			markdown = joinVertical(markdown, backlinks)
+		}
+
 		m.Viewport.SetContent(markdown)
+		if hasLink {
+			scrollToLink(&m.Viewport, markdown)
+		}
 		itemDetails = m.Viewport.View()
 	}
 
@@ -356,35 +376,62 @@ func buildTaskForFileView(m *Model, task Task, date string, view string, cursor
 	return view
 }
 
-func buildTaskFilesView(m *Model, line string, index int, file FileInfo, style lipgloss.Style, activeList string, completedList string, inactiveList string) (string, string, string, string) {
-	isInactive := m.TaskManager.TaskCollection.IsInactive(file.Name)
-	completed, total := m.TaskManager.TaskCollection.Progress(file.Name)
+// buildTaskFilesView renders the task files grouped into sections, by
+// status unless the selected saved view asks for another grouping.
+func buildTaskFilesView(m *Model) string {
+	files := m.FileManager.Files
+	if len(files) > 16 {
+		files = files[:16]
+	}
 
-	if total > 0 && completed == total {
-		if index != m.FileManager.FilesCursor {
-			style = completedFileStyle
-		}
-		completedList = joinVertical(completedList, style.Render(line))
-	} else if isInactive {
-		if index != m.FileManager.FilesCursor {
-			style = inactiveFileStyle
-		}
-		inactiveList = joinVertical(inactiveList, style.Render(line))
-	} else {
-		text := fmt.Sprintf("%d/%d", completed, total)
-		line = "[" + text + "] " + line
-		activeList = joinVertical(activeList, style.Render(line))
+	groupBy := ""
+	if view, ok := m.DirectoryManager.CurrentSavedView(); ok {
+		groupBy = view.GroupBy
 	}
 
-	activeTitle := taskFileTitleStyle.Render("Active")
-	inactiveTitle := inactiveTitleStyle().Render("Inactive")
-	completeTitle := completedTitleStyle().Render("Complete")
+	tc := &m.TaskManager.TaskCollection
+	var sections []string
 
-	renderedActiveList := renderedActiveListStyle().Render(activeList)
-	renderedInactiveList := renderedInactiveListStyle().Render(inactiveList)
-	renderedCompletedList := renderedCompletedListStyle().Render(completedList)
+	for _, group := range groupTaskFiles(files, groupBy, tc) {
+		list := ""
+
+		for _, index := range group.Files {
+			file := files[index]
+			line := file.FileNameWithoutExtension(m.FileManager.FileExtension)
+			style := defaultTextStyle
+
+			switch taskFileStatus(file.Name, tc) {
+			case completedFileGroup:
+				style = completedFileStyle
+			case inactiveFileGroup:
+				style = inactiveFileStyle
+			default:
+				line = taskFileProgressText(file.Name, tc) + line
+			}
+
+			if index == m.FileManager.FilesCursor {
+				style = highlightedTextStyle
+			}
+
+			list = joinVertical(list, style.Render(line))
+		}
+
+		switch group.Kind {
+		case activeFileGroup:
+			sections = append(sections, taskFileTitleStyle.Render(group.Title), renderedActiveListStyle().Render(list))
+		case inactiveFileGroup:
+			sections = append(sections, inactiveTitleStyle().Render(group.Title), renderedInactiveListStyle().Render(list))
+		case completedFileGroup:
+			sections = append(sections, completedTitleStyle().Render(group.Title), renderedCompletedListStyle().Render(list))
+		default:
+			if group.Title != "" {
+				sections = append(sections, taskFileTitleStyle.Render(group.Title))
+			}
+			sections = append(sections, renderedActiveListStyle().Render(list))
+		}
+	}
 
-	return joinVertical(activeTitle, renderedActiveList, inactiveTitle, renderedInactiveList, completeTitle, renderedCompletedList), activeList, completedList, inactiveList
+	return joinVertical(sections...)
 }
 
 func progressBar(completed, total int) string {
@@ -14,6 +14,18 @@ func (vc ViewControl) HandleKey(key string, m *Model) tea.Cmd {
 	switch key {
 	case "c":
 		return vc.ToggleCalendarView(m)
+	case "T":
+		return vc.ToggleTimelineView(m)
+	case "H":
+		return vc.ToggleHistoryView(m)
+	case "I":
+		return vc.ToggleStatsView(m)
+	case "G":
+		return vc.CycleKanbanSwimlanes(m)
+	case "0":
+		return DashboardControl{}.ToggleDashboard(m)
+	case "P":
+		return QuickPanelControl{}.ToggleQuickPanel(m)
 	case "w":
 		return vc.ToggleWeeklyView(m)
 	case "W":
@@ -41,9 +53,42 @@ func (vc ViewControl) HandleKey(key string, m *Model) tea.Cmd {
 // ToggleCalendarView toggles the calendar view on/off
 func (vc ViewControl) ToggleCalendarView(m *Model) tea.Cmd {
 	m.ViewManager.IsCalendarView = !m.ViewManager.IsCalendarView
+	m.ViewManager.IsTimelineView = false
+	m.ViewManager.IsStatsView = false
+	m.Calendar.DayOpen = false
+	m.Calendar.Moving = nil
 	return nil
 }
 
+// ToggleTimelineView toggles the timeline of task files on/off
+func (vc ViewControl) ToggleTimelineView(m *Model) tea.Cmd {
+	m.ViewManager.IsTimelineView = !m.ViewManager.IsTimelineView
+	m.ViewManager.IsCalendarView = false
+	m.ViewManager.IsStatsView = false
+	return nil
+}
+
+// ToggleStatsView toggles the flow metrics of the company's tasks on/off
+func (vc ViewControl) ToggleStatsView(m *Model) tea.Cmd {
+	m.ViewManager.IsStatsView = !m.ViewManager.IsStatsView
+	m.ViewManager.IsCalendarView = false
+	m.ViewManager.IsTimelineView = false
+	return nil
+}
+
+// ToggleHistoryView toggles the history pane of the selected task
+func (vc ViewControl) ToggleHistoryView(m *Model) tea.Cmd {
+	m.ViewManager.IsHistoryView = !m.ViewManager.IsHistoryView
+	return nil
+}
+
+// CycleKanbanSwimlanes groups the kanban view by file, company, priority or
+// nothing, in turn
+func (vc ViewControl) CycleKanbanSwimlanes(m *Model) tea.Cmd {
+	m.ViewManager.CycleKanbanSwimlanes()
+	return m.companyTasksCmd()
+}
+
 // ToggleWeeklyView toggles the weekly view on/off
 func (vc ViewControl) ToggleWeeklyView(m *Model) tea.Cmd {
 	m.ViewManager.ToggleWeeklyView()
@@ -123,6 +168,90 @@ func (cmd CKeyCommand) Contexts() []string {
 	return []string{}
 }
 
+type UppercaseTKeyCommand struct{}
+
+func (cmd UppercaseTKeyCommand) Execute(m *Model) tea.Cmd {
+	return ViewControl{}.ToggleTimelineView(m)
+}
+
+func (cmd UppercaseTKeyCommand) Description() string {
+	return "Toggle timeline view"
+}
+
+func (cmd UppercaseTKeyCommand) Contexts() []string {
+	return []string{}
+}
+
+type UppercaseGKeyCommand struct{}
+
+func (cmd UppercaseGKeyCommand) Execute(m *Model) tea.Cmd {
+	return ViewControl{}.CycleKanbanSwimlanes(m)
+}
+
+func (cmd UppercaseGKeyCommand) Description() string {
+	return "Cycle kanban swimlanes by file, company or priority"
+}
+
+func (cmd UppercaseGKeyCommand) Contexts() []string {
+	return []string{"kanban"}
+}
+
+type UppercaseHKeyCommand struct{}
+
+func (cmd UppercaseHKeyCommand) Execute(m *Model) tea.Cmd {
+	return ViewControl{}.ToggleHistoryView(m)
+}
+
+func (cmd UppercaseHKeyCommand) Description() string {
+	return "Toggle task history"
+}
+
+func (cmd UppercaseHKeyCommand) Contexts() []string {
+	return []string{}
+}
+
+type UppercaseIKeyCommand struct{}
+
+func (cmd UppercaseIKeyCommand) Execute(m *Model) tea.Cmd {
+	return ViewControl{}.ToggleStatsView(m)
+}
+
+func (cmd UppercaseIKeyCommand) Description() string {
+	return "Toggle flow stats view"
+}
+
+func (cmd UppercaseIKeyCommand) Contexts() []string {
+	return []string{}
+}
+
+type ZeroKeyCommand struct{}
+
+func (cmd ZeroKeyCommand) Execute(m *Model) tea.Cmd {
+	return DashboardControl{}.ToggleDashboard(m)
+}
+
+func (cmd ZeroKeyCommand) Description() string {
+	return "Toggle company dashboard"
+}
+
+func (cmd ZeroKeyCommand) Contexts() []string {
+	return []string{}
+}
+
+type UppercasePKeyCommand struct{}
+
+func (cmd UppercasePKeyCommand) Execute(m *Model) tea.Cmd {
+	return QuickPanelControl{}.ToggleQuickPanel(m)
+}
+
+func (cmd UppercasePKeyCommand) Description() string {
+	return "Toggle quick-access panel"
+}
+
+func (cmd UppercasePKeyCommand) Contexts() []string {
+	return []string{}
+}
+
 type WKeyCommand struct{}
 
 func (cmd WKeyCommand) Execute(m *Model) tea.Cmd {
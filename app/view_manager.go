@@ -17,9 +17,13 @@ type ViewManager struct {
 	SummaryViewHeight        int
 	IsAddTaskView            bool
 	IsAddSubTaskView         bool
+	IsAddMeetingView         bool
+	IsExtractActionItemsView bool
 	IsWeeklyView             bool
 	IsFilterView             bool
 	ShowCompanies            bool
+	KanbanColumns            []KanbanColumn
+	KanbanSwimlanes          string
 	KanbanListCursor         int
 	KanbanTaskCursor         int
 	KanbanTasksCount         int
@@ -29,6 +33,12 @@ type ViewManager struct {
 	SuggestionCursor         int
 	IsSuggestionsActive      bool
 	IsCalendarView           bool
+	IsTimelineView           bool
+	IsHistoryView            bool
+	IsStatsView              bool
+	IsDashboardView          bool
+	IsQuickPanelView         bool
+	IsSearchView             bool
 }
 
 const (
@@ -99,7 +109,8 @@ func (vm *ViewManager) GoToNextView(fm *FileManager, dm *DirectoryManager, tm *T
 	} else if vm.IsCategoryView() {
 		vm.CurrentView = DetailsView
 		fm.FilesCursor = 0
-		fm.Files = fm.FetchFiles(dm, tm)
+		fm.showCachedFiles(dm)
+		fm.RequestRefresh()
 	}
 }
 
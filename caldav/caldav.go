@@ -0,0 +1,203 @@
+// Package caldav is a small CalDAV (RFC 4791) client: it lists, writes and
+// deletes the todos of one calendar collection.
+package caldav
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrConflict is returned when an object changed on the server since its
+// ETag was read.
+var ErrConflict = errors.New("caldav: object changed on the server")
+
+const todoQuery = `<?xml version="1.0" encoding="utf-8"?>
+<C:calendar-query xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">
+  <D:prop>
+    <D:getetag/>
+    <C:calendar-data/>
+  </D:prop>
+  <C:filter>
+    <C:comp-filter name="VCALENDAR">
+      <C:comp-filter name="VTODO"/>
+    </C:comp-filter>
+  </C:filter>
+</C:calendar-query>`
+
+// Object is a calendar object resource: one .ics file of the collection.
+type Object struct {
+	Href string
+	ETag string
+	Data string
+}
+
+// Client talks to one calendar collection.
+type Client struct {
+	URL        string
+	Username   string
+	Password   string
+	HTTPClient *http.Client
+}
+
+func NewClient(collectionURL, username, password string) *Client {
+	if !strings.HasSuffix(collectionURL, "/") {
+		collectionURL += "/"
+	}
+
+	return &Client{URL: collectionURL, Username: username, Password: password, HTTPClient: http.DefaultClient}
+}
+
+type multistatus struct {
+	Responses []struct {
+		Href      string `xml:"DAV: href"`
+		Propstats []struct {
+			Status string `xml:"DAV: status"`
+			Prop   struct {
+				ETag string `xml:"DAV: getetag"`
+				Data string `xml:"urn:ietf:params:xml:ns:caldav calendar-data"`
+			} `xml:"DAV: prop"`
+		} `xml:"DAV: propstat"`
+	} `xml:"DAV: response"`
+}
+
+// Todos lists the objects of the collection that hold a VTODO.
+func (c *Client) Todos(ctx context.Context) ([]Object, error) {
+	request, err := c.request(ctx, "REPORT", c.URL, strings.NewReader(todoQuery))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	request.Header.Set("Depth", "1")
+
+	response, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusMultiStatus {
+		return nil, statusError("REPORT", c.URL, response)
+	}
+
+	var result multistatus
+	if err := xml.NewDecoder(response.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("caldav: invalid REPORT response: %w", err)
+	}
+
+	var objects []Object
+	for _, resp := range result.Responses {
+		for _, propstat := range resp.Propstats {
+			if !strings.Contains(propstat.Status, " 200 ") || propstat.Prop.Data == "" {
+				continue
+			}
+			objects = append(objects, Object{Href: resp.Href, ETag: propstat.Prop.ETag, Data: propstat.Prop.Data})
+		}
+	}
+
+	return objects, nil
+}
+
+// Put writes an object and returns its new ETag. An empty etag creates the
+// object, failing if it exists; otherwise the object must still have etag.
+func (c *Client) Put(ctx context.Context, href string, data []byte, etag string) (string, error) {
+	request, err := c.request(ctx, http.MethodPut, href, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "text/calendar; charset=utf-8")
+	setPrecondition(request, etag)
+
+	response, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return response.Header.Get("ETag"), nil
+	case http.StatusPreconditionFailed:
+		return "", ErrConflict
+	default:
+		return "", statusError("PUT", href, response)
+	}
+}
+
+// Delete removes an object, if it still has etag. Deleting an object that
+// is already gone succeeds.
+func (c *Client) Delete(ctx context.Context, href string, etag string) error {
+	request, err := c.request(ctx, http.MethodDelete, href, nil)
+	if err != nil {
+		return err
+	}
+	if etag != "" {
+		request.Header.Set("If-Match", etag)
+	}
+
+	response, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	case http.StatusPreconditionFailed:
+		return ErrConflict
+	default:
+		return statusError("DELETE", href, response)
+	}
+}
+
+// Href returns the path of the object with the given name in the
+// collection.
+func (c *Client) Href(name string) string {
+	collection, err := url.Parse(c.URL)
+	if err != nil {
+		return name
+	}
+	return collection.JoinPath(name).Path
+}
+
+func (c *Client) request(ctx context.Context, method, target string, body io.Reader) (*http.Request, error) {
+	base, err := url.Parse(c.URL)
+	if err != nil {
+		return nil, fmt.Errorf("caldav: invalid collection URL: %w", err)
+	}
+
+	reference, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("caldav: invalid href %q: %w", target, err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, base.ResolveReference(reference).String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Username != "" {
+		request.SetBasicAuth(c.Username, c.Password)
+	}
+
+	return request, nil
+}
+
+func setPrecondition(request *http.Request, etag string) {
+	if etag == "" {
+		request.Header.Set("If-None-Match", "*")
+	} else {
+		request.Header.Set("If-Match", etag)
+	}
+}
+
+func statusError(method, target string, response *http.Response) error {
+	return fmt.Errorf("caldav: %s %s: %s", method, target, response.Status)
+}
@@ -0,0 +1,59 @@
+package caldav
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"vision/caldav/caldavtest"
+)
+
+const todo = "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nUID:a\r\nSUMMARY:Ship it\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"
+
+func TestClient(t *testing.T) {
+	server := caldavtest.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.CollectionURL(), "user", "secret")
+	href := client.Href("a.ics")
+
+	if href != "/calendar/a.ics" {
+		t.Fatalf("unexpected href %q", href)
+	}
+
+	etag, err := client.Put(ctx, href, []byte(todo), "")
+	if err != nil || etag == "" {
+		t.Fatalf("expected the todo to be created, got %q %v", etag, err)
+	}
+
+	if _, err := client.Put(ctx, href, []byte(todo), ""); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected creating an existing todo to conflict, got %v", err)
+	}
+
+	server.Set("/calendar/event.ics", "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n")
+	changed := server.Set(href, todo+"\r\n")
+
+	objects, err := client.Todos(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 1 || objects[0].Href != href || objects[0].ETag != changed || objects[0].Data != todo+"\r\n" {
+		t.Fatalf("expected only the todo with its new ETag, got %+v", objects)
+	}
+
+	if _, err := client.Put(ctx, href, []byte(todo), etag); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected a stale ETag to conflict, got %v", err)
+	}
+
+	if err := client.Delete(ctx, href, etag); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected deleting with a stale ETag to conflict, got %v", err)
+	}
+
+	if err := client.Delete(ctx, href, changed); err != nil {
+		t.Errorf("expected the todo to be deleted, got %v", err)
+	}
+
+	if err := client.Delete(ctx, href, ""); err != nil {
+		t.Errorf("expected deleting a missing todo to succeed, got %v", err)
+	}
+}
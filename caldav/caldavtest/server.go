@@ -0,0 +1,160 @@
+// Package caldavtest provides an in-memory CalDAV collection for tests,
+// standing in for a server such as Radicale.
+package caldavtest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Server is a single calendar collection served at /calendar/.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	objects map[string]object
+	version int
+}
+
+type object struct {
+	etag string
+	data string
+}
+
+// NewServer starts a server. Close it when done.
+func NewServer() *Server {
+	server := &Server{objects: make(map[string]object)}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serve))
+	return server
+}
+
+// CollectionURL is the URL of the calendar collection.
+func (s *Server) CollectionURL() string {
+	return s.URL + "/calendar/"
+}
+
+// Set stores an object as another client would, and returns its ETag.
+func (s *Server) Set(href, data string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.store(href, data)
+}
+
+// Get returns the data of an object.
+func (s *Server) Get(href string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.objects[href]
+	return stored.data, ok
+}
+
+// Delete removes an object as another client would.
+func (s *Server) Delete(href string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, href)
+}
+
+// Hrefs lists the stored objects.
+func (s *Server) Hrefs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var hrefs []string
+	for href := range s.objects {
+		hrefs = append(hrefs, href)
+	}
+	sort.Strings(hrefs)
+	return hrefs
+}
+
+func (s *Server) store(href, data string) string {
+	s.version++
+	etag := fmt.Sprintf(`"%d"`, s.version)
+	s.objects[href] = object{etag: etag, data: data}
+	return etag
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !strings.HasPrefix(r.URL.Path, "/calendar/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	stored, exists := s.objects[r.URL.Path]
+	if match := r.Header.Get("If-Match"); match != "" && (!exists || stored.etag != match) {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+	if r.Header.Get("If-None-Match") == "*" && exists {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+
+	switch r.Method {
+	case "REPORT":
+		s.report(w)
+	case http.MethodGet:
+		if !exists {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("ETag", stored.etag)
+		io.WriteString(w, stored.data)
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		w.Header().Set("ETag", s.store(r.URL.Path, string(data)))
+		if exists {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusCreated)
+		}
+	case http.MethodDelete:
+		if !exists {
+			http.NotFound(w, r)
+			return
+		}
+		delete(s.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) report(w http.ResponseWriter) {
+	var hrefs []string
+	for href, stored := range s.objects {
+		if strings.Contains(stored.data, "BEGIN:VTODO") {
+			hrefs = append(hrefs, href)
+		}
+	}
+	sort.Strings(hrefs)
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+
+	io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?>`+"\n")
+	io.WriteString(w, `<D:multistatus xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">`)
+	for _, href := range hrefs {
+		stored := s.objects[href]
+		fmt.Fprintf(w, `<D:response><D:href>%s</D:href><D:propstat><D:prop><D:getetag>%s</D:getetag><C:calendar-data>%s</C:calendar-data></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`,
+			escape(href), escape(stored.etag), escape(stored.data))
+	}
+	io.WriteString(w, `</D:multistatus>`)
+}
+
+func escape(value string) string {
+	var builder strings.Builder
+	xml.EscapeText(&builder, []byte(value))
+	return builder.String()
+}
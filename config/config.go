@@ -14,10 +14,56 @@ type Company struct {
 	FullPath       string   `json:"fullPath"`
 	SubFolders     []string `json:"subFolders"`
 	Color          string   `json:"color"`
+	Calendars      []string `json:"calendars"`
+	ICSExport      string   `json:"icsExport"`
+	CalDAV         CalDAV   `json:"caldav"`
+}
+
+// CalDAV is the calendar collection scheduled tasks sync to as todos.
+type CalDAV struct {
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// SavedView is a named filter shown next to the category folders.
+type SavedView struct {
+	Name    string `json:"name"`
+	Query   string `json:"query"`
+	Company string `json:"company"`
+	SortBy  string `json:"sortBy"`
+	GroupBy string `json:"groupBy"`
+}
+
+// Reminder schedules a recurring meeting reminder. Time is "15:04" local
+// time and Weekdays are three letter day names such as "mon".
+type Reminder struct {
+	Company     string   `json:"company"`
+	Meeting     string   `json:"meeting"`
+	Time        string   `json:"time"`
+	Weekdays    []string `json:"weekdays"`
+	LeadMinutes int      `json:"leadMinutes"`
+	Standup     bool     `json:"standup"`
+	Notify      bool     `json:"notify"`
+}
+
+// KanbanColumn is a board of the kanban view. Tasks matching Query, a filter
+// query, are listed on it and moving a task onto it updates the task to
+// Status (unscheduled, scheduled, started or completed).
+type KanbanColumn struct {
+	Title  string `json:"title"`
+	Query  string `json:"query"`
+	Status string `json:"status"`
+	Color  string `json:"color"`
 }
 
 type Config struct {
-	Companies              []Company `json:"companies"`
+	Companies              []Company      `json:"companies"`
+	SavedViews             []SavedView    `json:"savedViews"`
+	Reminders              []Reminder     `json:"reminders"`
+	KanbanColumns          []KanbanColumn `json:"kanbanColumns"`
+	CacheSize              int            `json:"cacheSize"`
+	LinkOpener             string         `json:"linkOpener"`
 	Categories             []string
 	DefaultCompany         string
 	PreferredFileExtension string
@@ -56,6 +102,10 @@ func LoadConfig(path string) (*Config, error) {
 		config.PreferredFileExtension = os.Getenv("VISION_FILE_EXTENSION")
 	}
 
+	if config.LinkOpener == "" {
+		config.LinkOpener = "xdg-open"
+	}
+
 	log.Info("setting preferred file extension to: " + config.PreferredFileExtension)
 
 	return &config, nil
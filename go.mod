@@ -2,13 +2,15 @@ module vision
 
 go 1.21.4
 
-require github.com/charmbracelet/bubbletea v0.25.0
+require (
+	github.com/atotto/clipboard v0.1.4
+	github.com/charmbracelet/bubbletea v0.25.0
+	github.com/charmbracelet/lipgloss v0.9.1
+)
 
 require (
 	github.com/alecthomas/chroma v0.10.0 // indirect
-	github.com/atotto/clipboard v0.1.4 // indirect
 	github.com/aymerick/douceur v0.2.0 // indirect
-	github.com/charmbracelet/lipgloss v0.9.1 // indirect
 	github.com/cilium/ebpf v0.11.0 // indirect
 	github.com/dlclark/regexp2 v1.10.0 // indirect
 	github.com/go-delve/gore v0.11.6 // indirect
@@ -0,0 +1,184 @@
+package ical
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Event is a VEVENT. Overrides of one occurrence of a recurring event share
+// its UID and carry the RecurrenceID of the occurrence they replace.
+type Event struct {
+	UID          string
+	Summary      string
+	Description  string
+	Location     string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	Attendees    []string
+	Rule         *Rule
+	ExDates      []time.Time
+	RecurrenceID time.Time
+	Cancelled    bool
+}
+
+// Occurrence is one instance of an event.
+type Occurrence struct {
+	Event
+	Start time.Time
+	End   time.Time
+}
+
+func decodeEvent(component Component) (Event, error) {
+	event := Event{
+		UID:         component.Value("UID"),
+		Summary:     component.Value("SUMMARY"),
+		Description: component.Value("DESCRIPTION"),
+		Location:    component.Value("LOCATION"),
+		Cancelled:   strings.EqualFold(component.Value("STATUS"), "CANCELLED"),
+	}
+
+	start, ok := component.Get("DTSTART")
+	if !ok {
+		return Event{}, fmt.Errorf("event %q has no DTSTART", event.Summary)
+	}
+
+	var err error
+	if event.Start, event.AllDay, err = ParseTime(start); err != nil {
+		return Event{}, fmt.Errorf("event %q: %w", event.Summary, err)
+	}
+
+	switch {
+	case hasProperty(component, "DTEND"):
+		end, _ := component.Get("DTEND")
+		if event.End, _, err = ParseTime(end); err != nil {
+			return Event{}, fmt.Errorf("event %q: %w", event.Summary, err)
+		}
+	case hasProperty(component, "DURATION"):
+		duration, err := ParseDuration(component.Value("DURATION"))
+		if err != nil {
+			return Event{}, fmt.Errorf("event %q: %w", event.Summary, err)
+		}
+		event.End = event.Start.Add(duration)
+	case event.AllDay:
+		event.End = event.Start.AddDate(0, 0, 1)
+	default:
+		event.End = event.Start
+	}
+
+	if rule := component.Value("RRULE"); rule != "" {
+		if event.Rule, err = ParseRule(rule, event.Start.Location()); err != nil {
+			return Event{}, fmt.Errorf("event %q: %w", event.Summary, err)
+		}
+	}
+
+	for _, exdate := range component.All("EXDATE") {
+		for _, value := range strings.Split(exdate.Value, ",") {
+			excluded, _, err := ParseTime(Property{Name: "EXDATE", Params: exdate.Params, Value: value})
+			if err != nil {
+				return Event{}, fmt.Errorf("event %q: %w", event.Summary, err)
+			}
+			event.ExDates = append(event.ExDates, excluded)
+		}
+	}
+
+	if recurrenceID, ok := component.Get("RECURRENCE-ID"); ok {
+		if event.RecurrenceID, _, err = ParseTime(recurrenceID); err != nil {
+			return Event{}, fmt.Errorf("event %q: %w", event.Summary, err)
+		}
+	}
+
+	for _, attendee := range component.All("ATTENDEE") {
+		name := attendee.Params["CN"]
+		if name == "" {
+			name = strings.TrimPrefix(strings.TrimPrefix(attendee.Value, "mailto:"), "MAILTO:")
+		}
+		event.Attendees = append(event.Attendees, name)
+	}
+
+	return event, nil
+}
+
+func hasProperty(component Component, name string) bool {
+	_, ok := component.Get(name)
+	return ok
+}
+
+// Occurrences returns the instances of the event that overlap [from, to).
+func (e Event) Occurrences(from, to time.Time) []Occurrence {
+	duration := e.End.Sub(e.Start)
+	overlaps := func(start time.Time) bool {
+		if duration <= 0 {
+			return !start.Before(from) && start.Before(to)
+		}
+		return start.Before(to) && start.Add(duration).After(from)
+	}
+
+	if e.Rule == nil {
+		if overlaps(e.Start) {
+			return []Occurrence{{Event: e, Start: e.Start, End: e.End}}
+		}
+		return nil
+	}
+
+	var occurrences []Occurrence
+	e.Rule.each(e.Start, func(start time.Time) bool {
+		if !start.Before(to) {
+			return false
+		}
+
+		excluded := slices.ContainsFunc(e.ExDates, func(exdate time.Time) bool { return exdate.Equal(start) })
+		if !excluded && overlaps(start) {
+			occurrences = append(occurrences, Occurrence{Event: e, Start: start, End: start.Add(duration)})
+		}
+		return true
+	})
+
+	return occurrences
+}
+
+// Expand returns the occurrences of the events overlapping [from, to), with
+// overridden occurrences replaced and cancelled ones dropped, by start time.
+func Expand(events []Event, from, to time.Time) []Occurrence {
+	overrides := make(map[string][]Event)
+	for _, event := range events {
+		if !event.RecurrenceID.IsZero() {
+			overrides[event.UID] = append(overrides[event.UID], event)
+		}
+	}
+
+	var occurrences []Occurrence
+	for _, event := range events {
+		if !event.RecurrenceID.IsZero() || event.Cancelled {
+			continue
+		}
+
+		for _, occurrence := range event.Occurrences(from, to) {
+			if slices.ContainsFunc(overrides[event.UID], func(override Event) bool { return override.RecurrenceID.Equal(occurrence.Start) }) {
+				continue
+			}
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+
+	for _, eventOverrides := range overrides {
+		for _, override := range eventOverrides {
+			if override.Cancelled {
+				continue
+			}
+			override.Rule = nil
+			occurrences = append(occurrences, override.Occurrences(from, to)...)
+		}
+	}
+
+	slices.SortStableFunc(occurrences, func(a, b Occurrence) int {
+		if !a.Start.Equal(b.Start) {
+			return a.Start.Compare(b.Start)
+		}
+		return strings.Compare(a.Summary, b.Summary)
+	})
+
+	return occurrences
+}
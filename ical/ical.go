@@ -0,0 +1,296 @@
+// Package ical reads iCalendar (RFC 5545) files: the calendars exported from
+// work calendars and the events they contain.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Property is a content line such as DTSTART;TZID=Europe/Paris:20240305T093000.
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Component is a BEGIN/END block with its properties and nested components.
+type Component struct {
+	Name       string
+	Properties []Property
+	Components []Component
+}
+
+// Get returns the first property with the given name.
+func (c Component) Get(name string) (Property, bool) {
+	for _, property := range c.Properties {
+		if property.Name == name {
+			return property, true
+		}
+	}
+	return Property{}, false
+}
+
+// Value returns the unescaped text of the first property with the given name.
+func (c Component) Value(name string) string {
+	property, _ := c.Get(name)
+	return Unescape(property.Value)
+}
+
+// All returns every property with the given name.
+func (c Component) All(name string) []Property {
+	var properties []Property
+	for _, property := range c.Properties {
+		if property.Name == name {
+			properties = append(properties, property)
+		}
+	}
+	return properties
+}
+
+// Calendar is a parsed VCALENDAR.
+type Calendar struct {
+	Component
+	Events []Event
+	Todos  []Todo
+
+	// Skipped holds why the events and todos that could not be read were
+	// left out, so one bad component doesn't hide the rest of the calendar.
+	Skipped []error
+}
+
+// Parse reads a calendar. Components other than VEVENT and VTODO are kept
+// in the calendar's component tree. Events and todos that can't be decoded
+// are skipped and reported in Skipped.
+func Parse(r io.Reader) (*Calendar, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var stack []Component
+	var root *Component
+
+	for number, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		property, err := parseProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number+1, err)
+		}
+
+		switch property.Name {
+		case "BEGIN":
+			stack = append(stack, Component{Name: strings.ToUpper(property.Value)})
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(property.Value) {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", number+1, property.Value)
+			}
+
+			component := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			if len(stack) == 0 {
+				root = &component
+			} else {
+				stack[len(stack)-1].Components = append(stack[len(stack)-1].Components, component)
+			}
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: property outside of a component", number+1)
+			}
+			stack[len(stack)-1].Properties = append(stack[len(stack)-1].Properties, property)
+		}
+	}
+
+	if root == nil || root.Name != "VCALENDAR" || len(stack) > 0 {
+		return nil, fmt.Errorf("not a complete VCALENDAR")
+	}
+
+	calendar := &Calendar{Component: *root}
+	for _, component := range root.Components {
+		switch component.Name {
+		case "VEVENT":
+			event, err := decodeEvent(component)
+			if err != nil {
+				calendar.Skipped = append(calendar.Skipped, err)
+				continue
+			}
+			calendar.Events = append(calendar.Events, event)
+		case "VTODO":
+			todo, err := decodeTodo(component)
+			if err != nil {
+				calendar.Skipped = append(calendar.Skipped, err)
+				continue
+			}
+			calendar.Todos = append(calendar.Todos, todo)
+		}
+	}
+
+	return calendar, nil
+}
+
+// unfold joins continuation lines, which start with a space or a tab.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+func parseProperty(line string) (Property, error) {
+	inQuotes := false
+	colon := -1
+
+	for index, char := range line {
+		if char == '"' {
+			inQuotes = !inQuotes
+		} else if char == ':' && !inQuotes {
+			colon = index
+			break
+		}
+	}
+
+	if colon == -1 {
+		return Property{}, fmt.Errorf("missing ':' in %q", line)
+	}
+
+	parts := splitUnquoted(line[:colon], ';')
+	property := Property{Name: strings.ToUpper(parts[0]), Value: line[colon+1:]}
+
+	for _, param := range parts[1:] {
+		name, value, _ := strings.Cut(param, "=")
+		if property.Params == nil {
+			property.Params = make(map[string]string)
+		}
+		property.Params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+
+	return property, nil
+}
+
+func splitUnquoted(value string, separator rune) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+
+	for index, char := range value {
+		if char == '"' {
+			inQuotes = !inQuotes
+		} else if char == separator && !inQuotes {
+			parts = append(parts, value[start:index])
+			start = index + 1
+		}
+	}
+
+	return append(parts, value[start:])
+}
+
+// Unescape decodes a TEXT value.
+func Unescape(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
+
+// Escape encodes a TEXT value.
+func Escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, ",", `\,`, ";", `\;`).Replace(value)
+}
+
+// ParseTime reads a DATE or DATE-TIME property. Dates are all-day and fall
+// at midnight local time; times without a zone are local too.
+func ParseTime(property Property) (time.Time, bool, error) {
+	value := property.Value
+
+	if property.Params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		date, err := time.ParseInLocation("20060102", value, time.Local)
+		return date, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		utc, err := time.Parse("20060102T150405Z", value)
+		return utc, false, err
+	}
+
+	location := time.Local
+	if tzid := property.Params["TZID"]; tzid != "" {
+		if loaded, err := time.LoadLocation(tzid); err == nil {
+			location = loaded
+		}
+	}
+
+	local, err := time.ParseInLocation("20060102T150405", value, location)
+	return local, false, err
+}
+
+// ParseDuration reads a DURATION value such as PT1H30M or P1D.
+func ParseDuration(value string) (time.Duration, error) {
+	original := value
+	sign := time.Duration(1)
+
+	if strings.HasPrefix(value, "-") {
+		sign = -1
+	}
+	value = strings.TrimLeft(value, "+-")
+
+	if !strings.HasPrefix(value, "P") {
+		return 0, fmt.Errorf("invalid duration %q", original)
+	}
+	value = value[1:]
+
+	var total time.Duration
+	inTime := false
+	number := 0
+	digits := false
+
+	for _, char := range value {
+		switch {
+		case char >= '0' && char <= '9':
+			number = number*10 + int(char-'0')
+			digits = true
+			continue
+		case char == 'T':
+			inTime = true
+			continue
+		}
+
+		if !digits {
+			return 0, fmt.Errorf("invalid duration %q", original)
+		}
+
+		switch {
+		case char == 'W' && !inTime:
+			total += time.Duration(number) * 7 * 24 * time.Hour
+		case char == 'D' && !inTime:
+			total += time.Duration(number) * 24 * time.Hour
+		case char == 'H' && inTime:
+			total += time.Duration(number) * time.Hour
+		case char == 'M' && inTime:
+			total += time.Duration(number) * time.Minute
+		case char == 'S' && inTime:
+			total += time.Duration(number) * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", original)
+		}
+
+		number = 0
+		digits = false
+	}
+
+	return sign * total, nil
+}
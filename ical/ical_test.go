@@ -0,0 +1,211 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const workCalendar = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Test//EN
+BEGIN:VEVENT
+UID:standup@clerky
+SUMMARY:Standup
+DTSTART;TZID=America/New_York:20240304T093000
+DTEND;TZID=America/New_York:20240304T094500
+RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=6
+EXDATE;TZID=America/New_York:20240306T093000
+ATTENDEE;CN="Doe, Jane";ROLE=REQ-PARTICIPANT:mailto:jane@example.com
+ATTENDEE:mailto:john@example.com
+END:VEVENT
+BEGIN:VEVENT
+UID:standup@clerky
+RECURRENCE-ID;TZID=America/New_York:20240308T093000
+SUMMARY:Standup (moved)
+DTSTART;TZID=America/New_York:20240308T110000
+DURATION:PT15M
+END:VEVENT
+BEGIN:VEVENT
+UID:offsite@clerky
+SUMMARY:Offsite\, day one
+DESCRIPTION:Bring laptops\nand chargers
+DTSTART;VALUE=DATE:20240305
+DTEND;VALUE=DATE:20240306
+END:VEVENT
+BEGIN:VTODO
+UID:todo@clerky
+SUMMARY:Not an event
+END:VTODO
+END:VCALENDAR
+`
+
+func TestParse(t *testing.T) {
+	calendar, err := Parse(strings.NewReader(strings.ReplaceAll(workCalendar, "\n", "\r\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(calendar.Events) != 3 || len(calendar.Components) != 4 {
+		t.Fatalf("expected 3 events out of 4 components, got %d/%d", len(calendar.Events), len(calendar.Components))
+	}
+
+	standup := calendar.Events[0]
+	if standup.Rule == nil || standup.Rule.Count != 6 || len(standup.ExDates) != 1 || standup.End.Sub(standup.Start) != 15*time.Minute {
+		t.Errorf("unexpected recurring event %+v", standup)
+	}
+
+	if len(standup.Attendees) != 2 || standup.Attendees[0] != "Doe, Jane" || standup.Attendees[1] != "john@example.com" {
+		t.Errorf("unexpected attendees %v", standup.Attendees)
+	}
+
+	offsite := calendar.Events[2]
+	if offsite.Summary != "Offsite, day one" || offsite.Description != "Bring laptops\nand chargers" || !offsite.AllDay {
+		t.Errorf("unexpected all-day event %+v", offsite)
+	}
+}
+
+func TestParseRejectsIncompleteCalendars(t *testing.T) {
+	for _, input := range []string{"", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VCALENDAR\n", "SUMMARY:Orphan\n"} {
+		if _, err := Parse(strings.NewReader(input)); err == nil {
+			t.Errorf("expected %q to fail", input)
+		}
+	}
+}
+
+func TestParseSkipsBadComponents(t *testing.T) {
+	input := `BEGIN:VCALENDAR
+BEGIN:VEVENT
+UID:hourly@clerky
+SUMMARY:Hourly
+DTSTART:20240304T093000
+RRULE:FREQ=HOURLY
+END:VEVENT
+BEGIN:VEVENT
+UID:undated@clerky
+SUMMARY:Undated
+END:VEVENT
+BEGIN:VTODO
+UID:todo@clerky
+SUMMARY:Bad due
+DUE:tomorrow
+END:VTODO
+BEGIN:VEVENT
+UID:standup@clerky
+SUMMARY:Standup
+DTSTART:20240304T093000
+END:VEVENT
+END:VCALENDAR
+`
+
+	calendar, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(calendar.Events) != 1 || calendar.Events[0].Summary != "Standup" {
+		t.Errorf("expected only the readable event, got %+v", calendar.Events)
+	}
+	if len(calendar.Todos) != 0 || len(calendar.Skipped) != 3 {
+		t.Errorf("expected 3 skipped components, got %d todos and %v", len(calendar.Todos), calendar.Skipped)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	calendar, err := Parse(strings.NewReader(workCalendar))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone data unavailable")
+	}
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, newYork)
+	occurrences := Expand(calendar.Events, from, from.AddDate(0, 1, 0))
+
+	var got []string
+	for _, occurrence := range occurrences {
+		if occurrence.AllDay {
+			got = append(got, occurrence.Start.Format("Jan 2")+" "+occurrence.Summary)
+			continue
+		}
+		got = append(got, occurrence.Start.In(newYork).Format("Jan 2 15:04")+" "+occurrence.Summary)
+	}
+
+	// The 6th is excluded and the 8th moved, but COUNT still counts both
+	want := []string{
+		"Mar 4 09:30 Standup",
+		"Mar 5 Offsite, day one",
+		"Mar 8 11:00 Standup (moved)",
+		"Mar 11 09:30 Standup",
+		"Mar 13 09:30 Standup",
+		"Mar 15 09:30 Standup",
+	}
+
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestRuleFrequencies(t *testing.T) {
+	start := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		rule string
+		want []string
+	}{
+		{"FREQ=DAILY;INTERVAL=2;COUNT=3", []string{"2024-01-31", "2024-02-02", "2024-02-04"}},
+		{"FREQ=WEEKLY;UNTIL=20240214T100000Z", []string{"2024-01-31", "2024-02-07", "2024-02-14"}},
+		{"FREQ=MONTHLY;COUNT=3", []string{"2024-01-31", "2024-03-31", "2024-05-31"}},
+		{"FREQ=MONTHLY;BYDAY=-1FR;COUNT=2", []string{"2024-02-23", "2024-03-29"}},
+		{"FREQ=MONTHLY;BYDAY=1MO;COUNT=2", []string{"2024-02-05", "2024-03-04"}},
+		{"FREQ=MONTHLY;BYMONTHDAY=1,-1;COUNT=3", []string{"2024-01-31", "2024-02-01", "2024-02-29"}},
+		{"FREQ=YEARLY;COUNT=2", []string{"2024-01-31", "2025-01-31"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := ParseRule(tt.rule, time.UTC)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			event := Event{Start: start, End: start.Add(time.Hour), Rule: rule}
+
+			var got []string
+			for _, occurrence := range event.Occurrences(start, start.AddDate(3, 0, 0)) {
+				got = append(got, occurrence.Start.Format("2006-01-02"))
+			}
+
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := ParseRule("FREQ=HOURLY", time.UTC); err == nil {
+		t.Errorf("expected unsupported frequencies to fail")
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"PT1H30M": 90 * time.Minute,
+		"P1D":     24 * time.Hour,
+		"P1W":     7 * 24 * time.Hour,
+		"-PT15M":  -15 * time.Minute,
+		"P1DT2H":  26 * time.Hour,
+	}
+
+	for value, want := range tests {
+		if got, err := ParseDuration(value); err != nil || got != want {
+			t.Errorf("%s: expected %v, got %v %v", value, want, got, err)
+		}
+	}
+
+	if _, err := ParseDuration("1H"); err == nil {
+		t.Errorf("expected a duration without P to fail")
+	}
+}
@@ -0,0 +1,261 @@
+package ical
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRulePeriods bounds the periods a rule is expanded over, so a malformed
+// rule cannot loop forever.
+const maxRulePeriods = 100000
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// ByDay is a BYDAY entry. Ordinal is set for monthly and yearly rules such as
+// 1MO (first Monday) or -1FR (last Friday).
+type ByDay struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// Rule is the supported subset of RRULE: DAILY, WEEKLY, MONTHLY and YEARLY
+// frequencies with INTERVAL, COUNT, UNTIL, BYDAY and BYMONTHDAY.
+type Rule struct {
+	Frequency  string
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []ByDay
+	ByMonthDay []int
+}
+
+// ParseRule reads an RRULE value. Floating UNTIL values are read in location.
+func ParseRule(value string, location *time.Location) (*Rule, error) {
+	rule := &Rule{Interval: 1}
+
+	for _, part := range strings.Split(value, ";") {
+		name, partValue, _ := strings.Cut(part, "=")
+
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			rule.Frequency = strings.ToUpper(partValue)
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(partValue)
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(partValue)
+		case "UNTIL":
+			rule.Until, err = parseUntil(partValue, location)
+		case "BYDAY":
+			for _, day := range strings.Split(partValue, ",") {
+				byDay, dayErr := parseByDay(day)
+				if dayErr != nil {
+					return nil, dayErr
+				}
+				rule.ByDay = append(rule.ByDay, byDay)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(partValue, ",") {
+				monthDay, dayErr := strconv.Atoi(day)
+				if dayErr != nil {
+					return nil, fmt.Errorf("invalid BYMONTHDAY %q", day)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, monthDay)
+			}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid RRULE %s: %w", name, err)
+		}
+	}
+
+	switch rule.Frequency {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+	default:
+		return nil, fmt.Errorf("unsupported RRULE frequency %q", rule.Frequency)
+	}
+
+	if rule.Interval < 1 {
+		rule.Interval = 1
+	}
+
+	return rule, nil
+}
+
+func parseUntil(value string, location *time.Location) (time.Time, error) {
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		return time.Parse("20060102T150405Z", value)
+	case len(value) == len("20060102"):
+		date, err := time.ParseInLocation("20060102", value, location)
+		// A date includes the whole day
+		return date.AddDate(0, 0, 1).Add(-time.Second), err
+	default:
+		return time.ParseInLocation("20060102T150405", value, location)
+	}
+}
+
+func parseByDay(value string) (ByDay, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if len(value) < 2 {
+		return ByDay{}, fmt.Errorf("invalid BYDAY %q", value)
+	}
+
+	weekday, ok := weekdays[value[len(value)-2:]]
+	if !ok {
+		return ByDay{}, fmt.Errorf("invalid BYDAY %q", value)
+	}
+
+	byDay := ByDay{Weekday: weekday}
+	if ordinal := value[:len(value)-2]; ordinal != "" {
+		number, err := strconv.Atoi(ordinal)
+		if err != nil {
+			return ByDay{}, fmt.Errorf("invalid BYDAY %q", value)
+		}
+		byDay.Ordinal = number
+	}
+
+	return byDay, nil
+}
+
+// each calls yield with the rule's occurrences from start, in order, until
+// yield returns false or the rule ends.
+func (r *Rule) each(start time.Time, yield func(time.Time) bool) {
+	count := 0
+
+	for period := 0; period < maxRulePeriods; period++ {
+		for _, candidate := range r.periodCandidates(start, period) {
+			if candidate.Before(start) {
+				continue
+			}
+
+			if !r.Until.IsZero() && candidate.After(r.Until) {
+				return
+			}
+
+			count++
+			if r.Count > 0 && count > r.Count {
+				return
+			}
+
+			if !yield(candidate) {
+				return
+			}
+		}
+	}
+}
+
+// periodCandidates lists the occurrences of the rule's nth period after
+// start, in order.
+func (r *Rule) periodCandidates(start time.Time, period int) []time.Time {
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+	}
+	step := period * r.Interval
+
+	switch r.Frequency {
+	case "DAILY":
+		return []time.Time{at(start.Year(), start.Month(), start.Day()+step)}
+
+	case "WEEKLY":
+		if len(r.ByDay) == 0 {
+			return []time.Time{at(start.Year(), start.Month(), start.Day()+7*step)}
+		}
+
+		// Weeks start on Monday
+		offset := (int(start.Weekday()) + 6) % 7
+		monday := start.Day() - offset + 7*step
+
+		var candidates []time.Time
+		for day := 0; day < 7; day++ {
+			candidate := at(start.Year(), start.Month(), monday+day)
+			if r.hasWeekday(candidate.Weekday()) {
+				candidates = append(candidates, candidate)
+			}
+		}
+		return candidates
+
+	case "MONTHLY":
+		first := at(start.Year(), start.Month()+time.Month(step), 1)
+		return r.monthCandidates(first, start.Day(), at)
+
+	case "YEARLY":
+		if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+			candidate := at(start.Year()+step, start.Month(), start.Day())
+			if candidate.Day() != start.Day() {
+				return nil // No February 29th this year
+			}
+			return []time.Time{candidate}
+		}
+
+		first := at(start.Year()+step, start.Month(), 1)
+		return r.monthCandidates(first, start.Day(), at)
+	}
+
+	return nil
+}
+
+// monthCandidates lists the days of first's month matching the rule, or the
+// start day when the rule has no BYDAY or BYMONTHDAY.
+func (r *Rule) monthCandidates(first time.Time, startDay int, at func(int, time.Month, int) time.Time) []time.Time {
+	daysInMonth := time.Date(first.Year(), first.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	var days []int
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, day := range r.ByMonthDay {
+			if day < 0 {
+				day = daysInMonth + day + 1
+			}
+			if day >= 1 && day <= daysInMonth {
+				days = append(days, day)
+			}
+		}
+	case len(r.ByDay) > 0:
+		for day := 1; day <= daysInMonth; day++ {
+			weekday := at(first.Year(), first.Month(), day).Weekday()
+			for _, byDay := range r.ByDay {
+				if byDay.Weekday != weekday {
+					continue
+				}
+
+				nth := (day-1)/7 + 1
+				nthFromEnd := -((daysInMonth-day)/7 + 1)
+				if byDay.Ordinal == 0 || byDay.Ordinal == nth || byDay.Ordinal == nthFromEnd {
+					days = append(days, day)
+					break
+				}
+			}
+		}
+	default:
+		if startDay <= daysInMonth {
+			days = append(days, startDay)
+		}
+	}
+
+	var candidates []time.Time
+	for day := 1; day <= daysInMonth; day++ {
+		for _, candidateDay := range days {
+			if candidateDay == day {
+				candidates = append(candidates, at(first.Year(), first.Month(), day))
+				break
+			}
+		}
+	}
+
+	return candidates
+}
+
+func (r *Rule) hasWeekday(weekday time.Weekday) bool {
+	for _, byDay := range r.ByDay {
+		if byDay.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Todo is a VTODO, such as a reminder synced with a phone. Due dates
+// without a time are all-day.
+type Todo struct {
+	UID          string
+	Summary      string
+	Description  string
+	Due          time.Time
+	AllDay       bool
+	Completed    bool
+	LastModified time.Time
+}
+
+func decodeTodo(component Component) (Todo, error) {
+	todo := Todo{
+		UID:         component.Value("UID"),
+		Summary:     component.Value("SUMMARY"),
+		Description: component.Value("DESCRIPTION"),
+		Completed:   strings.EqualFold(component.Value("STATUS"), "COMPLETED") || hasProperty(component, "COMPLETED"),
+	}
+
+	var err error
+	if due, ok := component.Get("DUE"); ok {
+		if todo.Due, todo.AllDay, err = ParseTime(due); err != nil {
+			return Todo{}, fmt.Errorf("todo %q: %w", todo.Summary, err)
+		}
+	}
+
+	if modified, ok := component.Get("LAST-MODIFIED"); ok {
+		if todo.LastModified, _, err = ParseTime(modified); err != nil {
+			return Todo{}, fmt.Errorf("todo %q: %w", todo.Summary, err)
+		}
+	}
+
+	return todo, nil
+}
+
+// EncodeTodo writes a calendar holding a single todo, as CalDAV stores them.
+func EncodeTodo(w io.Writer, prodID string, todo Todo, stamp time.Time) error {
+	writer := bufio.NewWriter(w)
+
+	writeLine(writer, "BEGIN:VCALENDAR")
+	writeLine(writer, "VERSION:2.0")
+	writeLine(writer, "PRODID:"+prodID)
+	writeLine(writer, "BEGIN:VTODO")
+	writeLine(writer, "UID:"+todo.UID)
+	writeLine(writer, "DTSTAMP:"+formatUTC(stamp))
+	writeLine(writer, "SUMMARY:"+Escape(todo.Summary))
+
+	if todo.Description != "" {
+		writeLine(writer, "DESCRIPTION:"+Escape(todo.Description))
+	}
+
+	if !todo.Due.IsZero() {
+		if todo.AllDay {
+			writeLine(writer, "DUE;VALUE=DATE:"+todo.Due.Format("20060102"))
+		} else {
+			writeLine(writer, "DUE:"+formatUTC(todo.Due))
+		}
+	}
+
+	if !todo.LastModified.IsZero() {
+		writeLine(writer, "LAST-MODIFIED:"+formatUTC(todo.LastModified))
+	}
+
+	if todo.Completed {
+		writeLine(writer, "STATUS:COMPLETED")
+	} else {
+		writeLine(writer, "STATUS:NEEDS-ACTION")
+	}
+
+	writeLine(writer, "END:VTODO")
+	writeLine(writer, "END:VCALENDAR")
+	return writer.Flush()
+}
+
+// PatchTodo sets the DUE and LAST-MODIFIED of the todo with the UID in data,
+// a calendar as stored on a server. Every other line, such as alarms, notes
+// or categories, is kept as it is.
+func PatchTodo(data string, uid string, due time.Time, allDay bool, lastModified time.Time) (string, error) {
+	lines, err := unfold(strings.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+
+	var output strings.Builder
+	writer := bufio.NewWriter(&output)
+
+	// The lines of the todo being read, written once its UID is known
+	var todo []string
+	todoUID := ""
+	depth, todoDepth := 0, 0
+	found := false
+
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		property, err := parseProperty(line)
+		if err != nil {
+			return "", err
+		}
+
+		switch {
+		case property.Name == "BEGIN":
+			depth++
+			if todo == nil && strings.EqualFold(property.Value, "VTODO") {
+				todo, todoUID, todoDepth = []string{}, "", depth
+			}
+		case property.Name == "UID" && todo != nil && depth == todoDepth:
+			todoUID = property.Value
+		}
+
+		if todo == nil {
+			writeLine(writer, line)
+		} else {
+			todo = append(todo, line)
+		}
+
+		if property.Name != "END" {
+			continue
+		}
+
+		if todo != nil && depth == todoDepth {
+			if todoUID == uid {
+				todo = patchTodoLines(todo, due, allDay, lastModified)
+				found = true
+			}
+			for _, todoLine := range todo {
+				writeLine(writer, todoLine)
+			}
+			todo = nil
+		}
+		depth--
+	}
+
+	if !found {
+		return "", fmt.Errorf("no todo %q", uid)
+	}
+
+	if err := writer.Flush(); err != nil {
+		return "", err
+	}
+	return output.String(), nil
+}
+
+// patchTodoLines replaces the todo's own DUE and LAST-MODIFIED lines, leaving
+// the ones of nested components such as alarms alone.
+func patchTodoLines(lines []string, due time.Time, allDay bool, lastModified time.Time) []string {
+	var patched []string
+	depth := 0
+
+	for _, line := range lines {
+		property, _ := parseProperty(line)
+
+		switch property.Name {
+		case "BEGIN":
+			depth++
+		case "END":
+			depth--
+		case "DUE", "LAST-MODIFIED":
+			if depth == 1 {
+				continue
+			}
+		}
+
+		if property.Name == "END" && depth == 0 {
+			if allDay {
+				patched = append(patched, "DUE;VALUE=DATE:"+due.Format("20060102"))
+			} else {
+				patched = append(patched, "DUE:"+formatUTC(due))
+			}
+			patched = append(patched, "LAST-MODIFIED:"+formatUTC(lastModified))
+		}
+
+		patched = append(patched, line)
+	}
+
+	return patched
+}
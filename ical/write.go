@@ -0,0 +1,75 @@
+package ical
+
+import (
+	"bufio"
+	"io"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the longest content line RFC 5545 allows before folding.
+const maxLineOctets = 75
+
+// Encode writes events as a VCALENDAR. Stamp is the DTSTAMP of every event.
+// All-day events are written as dates, other events in UTC.
+func Encode(w io.Writer, prodID string, events []Event, stamp time.Time) error {
+	writer := bufio.NewWriter(w)
+
+	writeLine(writer, "BEGIN:VCALENDAR")
+	writeLine(writer, "VERSION:2.0")
+	writeLine(writer, "PRODID:"+prodID)
+	writeLine(writer, "CALSCALE:GREGORIAN")
+
+	for _, event := range events {
+		writeLine(writer, "BEGIN:VEVENT")
+		writeLine(writer, "UID:"+event.UID)
+		writeLine(writer, "DTSTAMP:"+formatUTC(stamp))
+
+		if event.AllDay {
+			writeLine(writer, "DTSTART;VALUE=DATE:"+event.Start.Format("20060102"))
+			writeLine(writer, "DTEND;VALUE=DATE:"+event.End.Format("20060102"))
+		} else {
+			writeLine(writer, "DTSTART:"+formatUTC(event.Start))
+			writeLine(writer, "DTEND:"+formatUTC(event.End))
+		}
+
+		writeLine(writer, "SUMMARY:"+Escape(event.Summary))
+		if event.Description != "" {
+			writeLine(writer, "DESCRIPTION:"+Escape(event.Description))
+		}
+		if event.Location != "" {
+			writeLine(writer, "LOCATION:"+Escape(event.Location))
+		}
+		if event.Cancelled {
+			writeLine(writer, "STATUS:CANCELLED")
+		}
+
+		writeLine(writer, "END:VEVENT")
+	}
+
+	writeLine(writer, "END:VCALENDAR")
+	return writer.Flush()
+}
+
+func formatUTC(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// writeLine writes a content line ending in CRLF, folded so no line exceeds
+// maxLineOctets. Folds never split a character.
+func writeLine(writer *bufio.Writer, line string) {
+	limit := maxLineOctets
+
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		writer.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1 // The leading space counts
+	}
+
+	writer.WriteString(line + "\r\n")
+}
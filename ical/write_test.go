@@ -0,0 +1,94 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeRoundTrips(t *testing.T) {
+	day := time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local)
+	start := time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC)
+	long := strings.Repeat("Réunion, budget; plan ", 8)
+
+	events := []Event{
+		{UID: "a@vision", Summary: "⏳ Ship it", Description: "Clerky / Release\nnotes", Start: day, End: day.AddDate(0, 0, 1), AllDay: true},
+		{UID: "b@vision", Summary: long, Location: "Office", Start: start, End: start.Add(time.Hour)},
+	}
+
+	var buffer bytes.Buffer
+	if err := Encode(&buffer, "-//test//EN", events, start); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(buffer.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+	}
+
+	calendar, err := Parse(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(calendar.Events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(calendar.Events))
+	}
+
+	allDay := calendar.Events[0]
+	if allDay.UID != "a@vision" || allDay.Summary != "⏳ Ship it" || allDay.Description != "Clerky / Release\nnotes" || !allDay.AllDay || !allDay.Start.Equal(day) {
+		t.Errorf("unexpected all-day event %+v", allDay)
+	}
+
+	timed := calendar.Events[1]
+	if timed.Summary != long || timed.Location != "Office" || !timed.Start.Equal(start) || timed.End.Sub(timed.Start) != time.Hour {
+		t.Errorf("unexpected timed event %+v", timed)
+	}
+}
+
+func TestPatchTodo(t *testing.T) {
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"PRODID:-//phone//EN",
+		"BEGIN:VTODO",
+		"UID:a@vision",
+		"SUMMARY:Ship it",
+		"DUE;VALUE=DATE:20240305",
+		"CATEGORIES:work",
+		"LAST-MODIFIED:20240301T090000Z",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"TRIGGER:-PT15M",
+		"END:VALARM",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	due := time.Date(2024, 3, 8, 0, 0, 0, 0, time.Local)
+	modified := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+
+	patched, err := PatchTodo(data, "a@vision", due, true, modified)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"CATEGORIES:work", "TRIGGER:-PT15M", "DUE;VALUE=DATE:20240308", "LAST-MODIFIED:20240306T120000Z"} {
+		if !strings.Contains(patched, want) {
+			t.Errorf("expected the todo to contain %q, got\n%s", want, patched)
+		}
+	}
+	if strings.Count(patched, "DUE") != 1 || strings.Count(patched, "LAST-MODIFIED") != 1 {
+		t.Errorf("expected the old dates to be replaced, got\n%s", patched)
+	}
+
+	calendar, err := Parse(strings.NewReader(patched))
+	if err != nil || len(calendar.Todos) != 1 || !calendar.Todos[0].Due.Equal(due) || len(calendar.Components[0].Components) != 1 {
+		t.Errorf("expected the patched todo with its alarm to parse, got %+v (%v)", calendar, err)
+	}
+
+	if _, err := PatchTodo(data, "b@vision", due, true, modified); err == nil {
+		t.Errorf("expected an error for a todo that is not in the calendar")
+	}
+}
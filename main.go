@@ -1,6 +1,9 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
+	"fmt"
 	"os"
 	"vision/app"
 	"vision/config"
@@ -20,6 +23,51 @@ func main() {
 	}
 
 	args := os.Args[1:]
+
+	if len(args) > 1 && args[0] == "index" && args[1] == "rebuild" {
+		count, err := app.RebuildFileIndex(cfg)
+		if err != nil {
+			log.Error("Failed to rebuild index", "error", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Indexed %d notes\n", count)
+		return
+	}
+
+	if len(args) > 1 && args[0] == "export" && args[1] == "ics" {
+		flags := flag.NewFlagSet("export ics", flag.ExitOnError)
+		company := flags.String("company", cfg.DefaultCompany, "company to export")
+		flags.Parse(args[2:])
+
+		if _, err := app.ExportICS(cfg, *company, os.Stdout); err != nil {
+			log.Error("Failed to export calendar", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(args) > 0 && args[0] == "stats" {
+		flags := flag.NewFlagSet("stats", flag.ExitOnError)
+		company := flags.String("company", cfg.DefaultCompany, "company to compute the stats of")
+		weeks := flags.Int("weeks", 12, "number of weeks to go back")
+		flags.Parse(args[1:])
+
+		metrics, err := app.FlowStats(cfg, *company, *weeks)
+		if err != nil {
+			log.Error("Failed to compute stats", "error", err)
+			os.Exit(1)
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(metrics); err != nil {
+			log.Error("Failed to write stats", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	initialModel := app.InitialModel(cfg, args) // Pass cmdline args to the model
 
 	p := tea.NewProgram(initialModel, tea.WithMouseCellMotion(), tea.WithAltScreen())